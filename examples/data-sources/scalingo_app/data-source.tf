@@ -0,0 +1,13 @@
+data "scalingo_app" "api" {
+  name = "my-api"
+}
+
+resource "scalingo_app" "front" {
+  name = "my-front"
+}
+
+resource "scalingo_environment_variable" "api_url" {
+  app   = scalingo_app.front.id
+  name  = "API_URL"
+  value = data.scalingo_app.api.url
+}
@@ -0,0 +1,22 @@
+resource "scalingo_app" "test_app" {
+  name = "terraform-testapp"
+}
+
+resource "scalingo_container_type" "web" {
+  app    = scalingo_app.test_app.id
+  name   = "web"
+  amount = 2
+}
+
+data "scalingo_app_containers" "test_app" {
+  app = scalingo_app.test_app.id
+
+  depends_on = [scalingo_container_type.web]
+}
+
+check "web_containers_running" {
+  assert {
+    condition     = lookup(data.scalingo_app_containers.test_app.running, "web", 0) == scalingo_container_type.web.amount
+    error_message = "Some web containers are not running"
+  }
+}
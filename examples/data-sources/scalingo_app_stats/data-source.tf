@@ -0,0 +1,16 @@
+resource "scalingo_app" "test_app" {
+  name = "terraform-testapp"
+}
+
+data "scalingo_app_stats" "test_app" {
+  app = scalingo_app.test_app.id
+}
+
+check "memory_usage" {
+  assert {
+    condition = alltrue([
+      for stat in data.scalingo_app_stats.test_app.stats : stat.memory_usage < 0.9 * stat.memory_limit
+    ])
+    error_message = "Some containers use more than 90% of their memory"
+  }
+}
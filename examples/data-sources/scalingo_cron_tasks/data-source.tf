@@ -0,0 +1,14 @@
+resource "scalingo_app" "test_app" {
+  name = "terraform-testapp"
+}
+
+data "scalingo_cron_tasks" "test_app" {
+  app = scalingo_app.test_app.id
+}
+
+check "cleanup_job_deployed" {
+  assert {
+    condition     = contains(data.scalingo_cron_tasks.test_app.commands, "0 3 * * * bundle exec rake cleanup")
+    error_message = "The cleanup job is not in the cron.json file of the deployed code"
+  }
+}
@@ -0,0 +1,13 @@
+resource "scalingo_app" "test_app" {
+  name = "terraform-testapp"
+}
+
+data "scalingo_events" "deployments" {
+  app   = scalingo_app.test_app.id
+  types = ["deployment"]
+  after = "2024-01-01T00:00:00Z"
+}
+
+output "last_deployed_git_ref" {
+  value = try(data.scalingo_events.deployments.events[0].deployment[0].git_ref, null)
+}
@@ -1,3 +1,8 @@
+variable "api_key" {
+  type      = string
+  sensitive = true
+}
+
 resource "scalingo_app" "test_app" {
   name = "terraform-testapp"
 
@@ -6,5 +11,10 @@ resource "scalingo_app" "test_app" {
     VARIABLE2 = "Value 2"
   }
 
+  # Hidden in the plan output
+  sensitive_environment = {
+    API_KEY = var.api_key
+  }
+
   force_https = true
 }
@@ -0,0 +1,20 @@
+resource "scalingo_app" "test_app" {
+  name = "terraform-testapp"
+}
+
+resource "scalingo_app_formation" "test_app" {
+  app                      = scalingo_app.test_app.id
+  scale_undeclared_to_zero = true
+
+  container_type {
+    name   = "web"
+    amount = 2
+    size   = "M"
+  }
+
+  container_type {
+    name   = "worker"
+    amount = 1
+    size   = "L"
+  }
+}
@@ -0,0 +1,17 @@
+variable "certificate_version" {
+  type        = string
+  description = "Version of the certificate read by the application at boot"
+}
+
+resource "scalingo_app" "test_app" {
+  name = "terraform-testapp"
+}
+
+resource "scalingo_app_restart" "certificate_rotation" {
+  app   = scalingo_app.test_app.id
+  scope = ["web"]
+
+  triggers = {
+    certificate_version = var.certificate_version
+  }
+}
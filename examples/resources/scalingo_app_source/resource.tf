@@ -0,0 +1,17 @@
+resource "scalingo_app" "test_app" {
+  name = "terraform-testapp"
+}
+
+# Deploy the content of the ./app directory, a new deployment is triggered
+# when a file of the directory changes
+resource "scalingo_app_source" "test_app" {
+  app  = scalingo_app.test_app.id
+  path = "${path.module}/app"
+
+  excludes = [
+    ".git",
+    "node_modules",
+    "*.log",
+    "/docs",
+  ]
+}
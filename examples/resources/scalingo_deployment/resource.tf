@@ -0,0 +1,20 @@
+resource "scalingo_app" "test_app" {
+  name = "terraform-testapp"
+}
+
+variable "release" {
+  type    = string
+  default = "v1.0.0"
+}
+
+# Deploy a tag of a GitHub repository, a new deployment is triggered when the
+# release changes
+resource "scalingo_deployment" "release" {
+  app        = scalingo_app.test_app.id
+  source_url = "https://github.com/my-org/my-app/archive/${var.release}.tar.gz"
+  git_ref    = var.release
+
+  timeouts {
+    create = "45m"
+  }
+}
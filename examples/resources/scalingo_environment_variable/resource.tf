@@ -0,0 +1,15 @@
+variable "sentry_dsn" {
+  type      = string
+  sensitive = true
+}
+
+resource "scalingo_app" "test_app" {
+  name = "terraform-testapp"
+}
+
+# Variable owned by another team than the one managing the application
+resource "scalingo_environment_variable" "sentry_dsn" {
+  app   = scalingo_app.test_app.id
+  name  = "SENTRY_DSN"
+  value = var.sentry_dsn
+}
@@ -0,0 +1,22 @@
+variable "release" {
+  type        = string
+  description = "Version of the deployed code"
+}
+
+resource "scalingo_app" "test_app" {
+  name = "terraform-testapp"
+}
+
+resource "scalingo_one_off" "migrations" {
+  app     = scalingo_app.test_app.id
+  command = "bundle exec rails db:migrate"
+  size    = "M"
+
+  environment = {
+    VERBOSE = "true"
+  }
+
+  triggers = {
+    release = var.release
+  }
+}
@@ -93,7 +93,7 @@ func dataSourceScAddonProvider() *schema.Resource {
 							Description: "Can the plan be provisioned?",
 						},
 						"disabled_alternative_plan_id": {
-							Type:        schema.TypeBool,
+							Type:        schema.TypeString,
 							Computed:    true,
 							Description: "Filled if disabled: alternative plan to provision instead",
 						},
@@ -116,11 +116,11 @@ func dataSourceScAddonProvider() *schema.Resource {
 }
 
 func dataSourceScAddonProviderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	name, _ := d.Get("name").(string)
 
-	addonProviders, err := client.AddonProvidersList(ctx)
+	addonProviders, err := client.catalog.AddonProviders(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
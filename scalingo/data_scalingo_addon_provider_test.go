@@ -0,0 +1,26 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestDataSourceAddonProvider(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	state := mustReadDataSource(t, "scalingo_addon_providers", meta, map[string]any{
+		"name": "PostgreSQL NG",
+	})
+	if state.ID != "postgresql-ng" {
+		t.Errorf("expected ID postgresql-ng, got %s", state.ID)
+	}
+	assertAttr(t, state, "category.name", "Databases")
+	assertAttr(t, state, "plans.#", "2")
+	assertAttr(t, state, "plans.0.name", "postgresql-ng-starter-4096")
+
+	_, diags := readDataSource(t, "scalingo_addon_providers", meta, map[string]any{
+		"name": "Unknown",
+	})
+	if !diags.HasError() {
+		t.Error("expected an error for an unknown addon provider")
+	}
+}
@@ -0,0 +1,348 @@
+package scalingo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func dataSourceScApp() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScAppRead,
+		Description: "Data source representing an application, looked up by ID or by name, for instance to reference an application managed by another workspace",
+
+		Schema: mergeSchemas(appAttributesSchema(), map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "ID of the application",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+				Description:  "Name of the application",
+			},
+			"container_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Container types of the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the container type (web, worker, etc.)",
+						},
+						"amount": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of containers of this type",
+						},
+						"size": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Size of the containers of this type",
+						},
+						"command": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Command overriding the one of the Procfile, if any",
+						},
+					},
+				},
+			},
+			"addons": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Addons of the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the addon",
+						},
+						"provider_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the addon provider (postgresql, redis, etc.)",
+						},
+						"plan": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the plan of the addon",
+						},
+						"plan_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the plan of the addon",
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the resource of the addon",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the addon",
+						},
+					},
+				},
+			},
+			"environment": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Environment variables of the application, including the ones added by its addons",
+			},
+		}),
+	}
+}
+
+func dataSourceScAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appRef, _ := d.Get("id").(string)
+	if appRef == "" {
+		appRef, _ = d.Get("name").(string)
+	}
+
+	app, err := client.AppsShow(ctx, appRef)
+	if err != nil {
+		return diag.Errorf("fetch application %v: %v", appRef, err)
+	}
+
+	containerTypes, err := client.AppsContainerTypes(ctx, app.ID)
+	if err != nil {
+		return diag.Errorf("list container types: %v", err)
+	}
+	addons, err := client.AddonsList(ctx, app.ID)
+	if err != nil {
+		return diag.Errorf("list addons: %v", err)
+	}
+	environment, err := appEnvironment(ctx, client, app.ID)
+	if err != nil {
+		return diag.Errorf("fetch application environment: %v", err)
+	}
+
+	attributes := appAttributes(app)
+	attributes["container_types"] = flattenContainerTypes(containerTypes)
+	attributes["addons"] = flattenAddons(addons)
+	attributes["environment"] = environment
+
+	d.SetId(app.ID)
+	err = SetAll(d, attributes)
+	if err != nil {
+		return diag.Errorf("store application information: %v", err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Fetched application '%s' with ID %s", app.Name, app.ID))
+
+	return nil
+}
+
+// appAttributesSchema is the schema of the attributes returned by
+// appAttributes.
+func appAttributesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"region": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Region of the application",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Status of the application (new, running, stopped, etc.)",
+		},
+		"url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "URL (https://*) to access the application",
+		},
+		"base_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Base URL (https://*), generated by Scalingo, to access the application",
+		},
+		"git_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Hostname to use to deploy code with Git + SSH",
+		},
+		"stack_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the base stack of the application",
+		},
+		"project_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the project to which the application belongs to",
+		},
+		"project_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the project to which the application belongs to",
+		},
+		"owner_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the owner of the application",
+		},
+		"owner_username": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Username of the owner of the application",
+		},
+		"owner_email": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Email of the owner of the application",
+		},
+		"created_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Creation date of the application (RFC 3339)",
+		},
+		"last_deployed_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Date of the last deployment of the application (RFC 3339), empty if it has never been deployed",
+		},
+		"last_deployed_by": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Username of the user who made the last deployment of the application",
+		},
+		"force_https": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether HTTP traffic is redirected to HTTPS",
+		},
+		"router_logs": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether Router Logs are enabled",
+		},
+		"sticky_session": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the Sticky Session feature is enabled",
+		},
+		"hds_resource": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the application is an HDS resource",
+		},
+		"flags": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeBool},
+			Description: "Feature flags enabled or disabled on the application",
+		},
+		"limits": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Limits of the application (e.g. the maximum number of containers), formatted as strings",
+		},
+		"private_network_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "IDs of the private networks of the application",
+		},
+	}
+}
+
+// appAttributes returns the attributes of the application data sources
+// describing the application itself.
+func appAttributes(app *scalingo.App) map[string]interface{} {
+	limits := make(map[string]interface{}, len(app.Limits))
+	for name, limit := range app.Limits {
+		limits[name] = fmt.Sprint(limit)
+	}
+	flags := make(map[string]interface{}, len(app.Flags))
+	for name, flag := range app.Flags {
+		flags[name] = flag
+	}
+
+	return map[string]interface{}{
+		"name":                app.Name,
+		"region":              app.Region,
+		"status":              string(app.Status),
+		"url":                 app.URL,
+		"base_url":            app.BaseURL,
+		"git_url":             app.GitURL,
+		"stack_id":            app.StackID,
+		"project_id":          app.Project.ID,
+		"project_name":        app.Project.Name,
+		"owner_id":            app.Owner.ID,
+		"owner_username":      app.Owner.Username,
+		"owner_email":         app.Owner.Email,
+		"created_at":          formatTime(app.CreatedAt),
+		"last_deployed_at":    formatTime(app.LastDeployedAt),
+		"last_deployed_by":    app.LastDeployedBy,
+		"force_https":         app.ForceHTTPS,
+		"router_logs":         app.RouterLogs,
+		"sticky_session":      app.StickySession,
+		"hds_resource":        app.HDSResource,
+		"flags":               flags,
+		"limits":              limits,
+		"private_network_ids": app.PrivateNetworksIDs,
+	}
+}
+
+func flattenContainerTypes(containerTypes []scalingo.ContainerType) []interface{} {
+	result := make([]interface{}, 0, len(containerTypes))
+	for _, containerType := range containerTypes {
+		result = append(result, map[string]interface{}{
+			"name":    containerType.Name,
+			"amount":  containerType.Amount,
+			"size":    containerType.Size,
+			"command": containerType.Command,
+		})
+	}
+	return result
+}
+
+func flattenAddons(addons []*scalingo.Addon) []interface{} {
+	result := make([]interface{}, 0, len(addons))
+	for _, addon := range addons {
+		flattened := map[string]interface{}{
+			"id":          addon.ID,
+			"resource_id": addon.ResourceID,
+			"status":      string(addon.Status),
+		}
+		if addon.AddonProvider != nil {
+			flattened["provider_id"] = addon.AddonProvider.ID
+		}
+		if addon.Plan != nil {
+			flattened["plan"] = addon.Plan.Name
+			flattened["plan_id"] = addon.Plan.ID
+		}
+		result = append(result, flattened)
+	}
+	return result
+}
+
+// formatTime formats an optional date of the API as RFC 3339, an empty string
+// if it is not set.
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
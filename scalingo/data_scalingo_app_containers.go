@@ -0,0 +1,120 @@
+package scalingo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceScAppContainers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScAppContainersRead,
+		Description: "Containers of an application, including the one-off containers, e.g. to check in a `check` block that every container type is running",
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the targeted application",
+			},
+			"running": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Number of running containers by container type",
+			},
+			"containers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Containers of the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the container",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the container, e.g. web-1",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Container type of the container (web, worker, one-off, etc.)",
+						},
+						"index": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Index of the container among the containers of its type",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the container (booting, running, crashed, etc.)",
+						},
+						"size": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Size of the container",
+						},
+						"command": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Command run by the container",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Creation date of the container (RFC 3339)",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceScAppContainersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+
+	containers, err := client.AppsContainersPs(ctx, appID)
+	if err != nil {
+		return diag.Errorf("list containers: %v", err)
+	}
+
+	running := map[string]interface{}{}
+	flattened := make([]interface{}, 0, len(containers))
+	for _, container := range containers {
+		if container.State == containerStateRunning {
+			count, _ := running[container.Type].(int)
+			running[container.Type] = count + 1
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"id":         container.ID,
+			"name":       fmt.Sprintf("%v-%d", container.Type, container.TypeIndex),
+			"type":       container.Type,
+			"index":      container.TypeIndex,
+			"state":      container.State,
+			"size":       container.ContainerSize.Name,
+			"command":    container.Command,
+			"created_at": formatTime(container.CreatedAt),
+		})
+	}
+
+	err = SetAll(d, map[string]interface{}{
+		"running":    running,
+		"containers": flattened,
+	})
+	if err != nil {
+		return diag.Errorf("store containers: %v", err)
+	}
+	d.SetId(appID)
+
+	return nil
+}
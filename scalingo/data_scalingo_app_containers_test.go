@@ -0,0 +1,34 @@
+package scalingo
+
+import (
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestDataSourceAppContainers(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	app.containers = []scalingo.ContainerType{
+		{AppID: app.ID, Name: "web", Amount: 2, Size: "L"},
+		{AppID: app.ID, Name: "worker", Amount: 1, Size: "M"},
+	}
+	app.containerStates = map[string]string{"worker": "crashed"}
+
+	state := mustReadDataSource(t, "scalingo_app_containers", meta, map[string]any{"app": app.ID})
+	if state.ID != app.ID {
+		t.Errorf("expected ID %s, got %s", app.ID, state.ID)
+	}
+	assertAttr(t, state, "containers.#", "3")
+	assertAttr(t, state, "containers.1.name", "web-2")
+	assertAttr(t, state, "containers.1.type", "web")
+	assertAttr(t, state, "containers.1.index", "2")
+	assertAttr(t, state, "containers.1.state", "running")
+	assertAttr(t, state, "containers.1.size", "L")
+	assertAttr(t, state, "containers.2.state", "crashed")
+	assertAttr(t, state, "running.%", "1")
+	assertAttr(t, state, "running.web", "2")
+
+	_, diags := readDataSource(t, "scalingo_app_containers", meta, map[string]any{"app": "unknown"})
+	assertDiagContains(t, diags, "list containers")
+}
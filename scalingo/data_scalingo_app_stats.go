@@ -0,0 +1,108 @@
+package scalingo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceScAppStats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScAppStatsRead,
+		Description: "Current resources usage of the running containers of an application",
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the targeted application",
+			},
+			"stats": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Resources usage of each running container",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the container, e.g. web-1",
+						},
+						"cpu_usage": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "CPU usage of the container, in percent",
+						},
+						"memory_usage": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Memory used by the container, in bytes",
+						},
+						"memory_limit": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Memory limit of the container, in bytes",
+						},
+						"highest_memory_usage": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Highest memory used by the container, in bytes",
+						},
+						"swap_usage": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Swap used by the container, in bytes",
+						},
+						"swap_limit": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Swap limit of the container, in bytes",
+						},
+						"highest_swap_usage": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Highest swap used by the container, in bytes",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceScAppStatsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+
+	res, err := client.AppsStats(ctx, appID)
+	if err != nil {
+		return diag.Errorf("get application stats: %v", err)
+	}
+
+	stats := make([]interface{}, 0, len(res.Stats))
+	for _, stat := range res.Stats {
+		if stat == nil {
+			continue
+		}
+		stats = append(stats, map[string]interface{}{
+			"id":                   stat.ID,
+			"cpu_usage":            stat.CPUUsage,
+			"memory_usage":         int(stat.MemoryUsage),
+			"memory_limit":         int(stat.MemoryLimit),
+			"highest_memory_usage": int(stat.HighestMemoryUsage),
+			"swap_usage":           int(stat.SwapUsage),
+			"swap_limit":           int(stat.SwapLimit),
+			"highest_swap_usage":   int(stat.HighestSwapUsage),
+		})
+	}
+
+	err = d.Set("stats", stats)
+	if err != nil {
+		return diag.Errorf("store application stats: %v", err)
+	}
+	d.SetId(appID)
+
+	return nil
+}
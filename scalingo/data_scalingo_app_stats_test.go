@@ -0,0 +1,30 @@
+package scalingo
+
+import (
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestDataSourceAppStats(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	app.containers = []scalingo.ContainerType{
+		{AppID: app.ID, Name: "web", Amount: 2, Size: "L"},
+	}
+
+	state := mustReadDataSource(t, "scalingo_app_stats", meta, map[string]any{"app": app.ID})
+	if state.ID != app.ID {
+		t.Errorf("expected ID %s, got %s", app.ID, state.ID)
+	}
+	assertAttr(t, state, "stats.#", "2")
+	assertAttr(t, state, "stats.1.id", "web-2")
+	assertAttr(t, state, "stats.1.cpu_usage", "10")
+	assertAttr(t, state, "stats.1.memory_usage", "536870912")
+	assertAttr(t, state, "stats.1.memory_limit", "1073741824")
+	assertAttr(t, state, "stats.1.highest_memory_usage", "805306368")
+	assertAttr(t, state, "stats.1.swap_usage", "0")
+
+	_, diags := readDataSource(t, "scalingo_app_stats", meta, map[string]any{"app": "unknown"})
+	assertDiagContains(t, diags, "get application stats")
+}
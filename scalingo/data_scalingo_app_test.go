@@ -0,0 +1,59 @@
+package scalingo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestDataSourceApp(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app", ProjectID: "pr-default"})
+	deployedAt := time.Date(2024, 3, 14, 10, 0, 0, 0, time.UTC)
+	app.LastDeployedAt = &deployedAt
+	app.LastDeployedBy = "deployer"
+	app.Flags["sticky-session"] = true
+	app.Limits["max_containers"] = 10
+	app.PrivateNetworksIDs = []string{"pn-1"}
+	app.setVariable(f, "FOO", "bar")
+	addon := mustApplyResource(t, "scalingo_addon", meta, nil, map[string]any{
+		"app":         app.ID,
+		"provider_id": "postgresql",
+		"plan":        "postgresql-starter-512",
+	})
+
+	for _, lookup := range []map[string]any{{"name": "my-app"}, {"id": app.ID}} {
+		state := mustReadDataSource(t, "scalingo_app", meta, lookup)
+		if state.ID != app.ID {
+			t.Errorf("expected ID %s, got %s", app.ID, state.ID)
+		}
+		assertAttr(t, state, "name", "my-app")
+		assertAttr(t, state, "region", app.Region)
+		assertAttr(t, state, "git_url", app.GitURL)
+		assertAttr(t, state, "stack_id", "st-scalingo-22")
+		assertAttr(t, state, "project_id", "pr-default")
+		assertAttr(t, state, "owner_email", fakeOwner.Email)
+		assertAttr(t, state, "last_deployed_at", "2024-03-14T10:00:00Z")
+		assertAttr(t, state, "last_deployed_by", "deployer")
+		assertAttr(t, state, "flags.sticky-session", "true")
+		assertAttr(t, state, "limits.max_containers", "10")
+		assertAttr(t, state, "private_network_ids.0", "pn-1")
+		assertAttr(t, state, "container_types.#", "1")
+		assertAttr(t, state, "container_types.0.name", "web")
+		assertAttr(t, state, "container_types.0.size", "M")
+		assertAttr(t, state, "addons.#", "1")
+		assertAttr(t, state, "addons.0.id", addon.ID)
+		assertAttr(t, state, "addons.0.provider_id", "postgresql")
+		assertAttr(t, state, "addons.0.plan", "postgresql-starter-512")
+		assertAttr(t, state, "environment.FOO", "bar")
+	}
+
+	_, diags := readDataSource(t, "scalingo_app", meta, map[string]any{"name": "unknown"})
+	assertDiagContains(t, diags, "fetch application unknown")
+
+	_, diags = readDataSource(t, "scalingo_app", meta, map[string]any{"name": "my-app", "id": app.ID})
+	if !diags.HasError() {
+		t.Error("expected an error when both the ID and the name are set")
+	}
+}
@@ -0,0 +1,173 @@
+package scalingo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func dataSourceScApps() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScAppsRead,
+		Description: "List of the applications the user has access to, optionally filtered, e.g. to configure all the applications of a project with `for_each`",
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only keep the applications of this project",
+			},
+			"owner": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only keep the applications owned by this user, given by ID, username or email",
+			},
+			"stack_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only keep the applications based on this stack",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only keep the applications of this region",
+			},
+			"hds_resource": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Only keep the applications which are HDS resources if true, the ones which are not if false",
+			},
+			"name_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateRegexp,
+				Description:      "Only keep the applications whose name matches this regular expression (RE2 syntax), e.g. `^api-`",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the applications, sorted by name",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the applications, sorted by name",
+			},
+			"apps": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Applications, sorted by name",
+				Elem: &schema.Resource{
+					Schema: mergeSchemas(appAttributesSchema(), map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the application",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the application",
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceScAppsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	apps, err := client.AppsList(ctx)
+	if err != nil {
+		return diag.Errorf("list applications: %v", err)
+	}
+
+	projectID, _ := d.Get("project_id").(string)
+	owner, _ := d.Get("owner").(string)
+	stackID, _ := d.Get("stack_id").(string)
+	region, _ := d.Get("region").(string)
+	var nameRegexp *regexp.Regexp
+	if pattern, _ := d.Get("name_regex").(string); pattern != "" {
+		nameRegexp, err = regexp.Compile(pattern)
+		if err != nil {
+			return diag.Errorf("invalid name_regex: %v", err)
+		}
+	}
+	// d.GetOk can't tell a false boolean apart from an unset one.
+	//nolint:staticcheck // GetOkExists is deprecated but does it.
+	hdsResource, filterHDSResource := d.GetOkExists("hds_resource")
+
+	apps = keepIf(apps, func(app *scalingo.App) bool {
+		switch {
+		case projectID != "" && app.Project.ID != projectID:
+			return false
+		case owner != "" && owner != app.Owner.ID && owner != app.Owner.Username && !strings.EqualFold(owner, app.Owner.Email):
+			return false
+		case stackID != "" && app.StackID != stackID:
+			return false
+		case region != "" && app.Region != region:
+			return false
+		case filterHDSResource && app.HDSResource != hdsResource.(bool):
+			return false
+		case nameRegexp != nil && !nameRegexp.MatchString(app.Name):
+			return false
+		}
+		return true
+	})
+	slices.SortFunc(apps, func(a, b *scalingo.App) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	ids := make([]string, 0, len(apps))
+	names := make([]string, 0, len(apps))
+	flattenedApps := make([]interface{}, 0, len(apps))
+	for _, app := range apps {
+		ids = append(ids, app.ID)
+		names = append(names, app.Name)
+		attributes := appAttributes(app)
+		attributes["id"] = app.ID
+		flattenedApps = append(flattenedApps, attributes)
+	}
+
+	err = SetAll(d, map[string]interface{}{
+		"ids":   ids,
+		"names": names,
+		"apps":  flattenedApps,
+	})
+	if err != nil {
+		return diag.Errorf("store applications: %v", err)
+	}
+
+	// The ID changes with the list of applications.
+	hash := sha256.Sum256([]byte(strings.Join(ids, ",")))
+	d.SetId(hex.EncodeToString(hash[:]))
+
+	return nil
+}
+
+func validateRegexp(value interface{}, path cty.Path) diag.Diagnostics {
+	pattern, _ := value.(string)
+	_, err := regexp.Compile(pattern)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("invalid regular expression %q: %v", pattern, err),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package scalingo
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestDataSourceApps(t *testing.T) {
+	f, meta := newTestProvider(t)
+	api := f.createApp(scalingo.AppsCreateOpts{Name: "api-prod", ProjectID: "pr-prod", HDSResource: true})
+	front := f.createApp(scalingo.AppsCreateOpts{Name: "front-prod", ProjectID: "pr-prod", StackID: "st-scalingo-20"})
+	staging := f.createApp(scalingo.AppsCreateOpts{Name: "api-staging", ProjectID: "pr-staging"})
+	staging.Owner = scalingo.Owner{ID: "us-other", Username: "other", Email: "other@fake.test"}
+
+	state := mustReadDataSource(t, "scalingo_apps", meta, map[string]any{})
+	assertAttr(t, state, "names.#", "3")
+	// The applications are sorted by name.
+	assertAttr(t, state, "names.0", "api-prod")
+	assertAttr(t, state, "names.1", "api-staging")
+	assertAttr(t, state, "ids.2", front.ID)
+	assertAttr(t, state, "apps.0.id", api.ID)
+	assertAttr(t, state, "apps.0.project_id", "pr-prod")
+	assertAttr(t, state, "apps.0.hds_resource", "true")
+	assertAttr(t, state, "apps.2.stack_id", "st-scalingo-20")
+	allID := state.ID
+
+	tests := map[string]struct {
+		filters  map[string]any
+		expected []string
+	}{
+		"project":        {filters: map[string]any{"project_id": "pr-prod"}, expected: []string{"api-prod", "front-prod"}},
+		"owner email":    {filters: map[string]any{"owner": "OTHER@fake.test"}, expected: []string{"api-staging"}},
+		"owner username": {filters: map[string]any{"owner": fakeOwner.Username}, expected: []string{"api-prod", "front-prod"}},
+		"stack":          {filters: map[string]any{"stack_id": "st-scalingo-20"}, expected: []string{"front-prod"}},
+		"region":         {filters: map[string]any{"region": "other-region"}, expected: []string{}},
+		"HDS":            {filters: map[string]any{"hds_resource": true}, expected: []string{"api-prod"}},
+		"not HDS":        {filters: map[string]any{"hds_resource": false}, expected: []string{"api-staging", "front-prod"}},
+		"name regex":     {filters: map[string]any{"name_regex": "^api-"}, expected: []string{"api-prod", "api-staging"}},
+		"all filters": {
+			filters:  map[string]any{"project_id": "pr-prod", "owner": fakeOwner.ID, "region": api.Region, "name_regex": "prod$", "hds_resource": false},
+			expected: []string{"front-prod"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			state := mustReadDataSource(t, "scalingo_apps", meta, test.filters)
+			assertAttr(t, state, "names.#", strconv.Itoa(len(test.expected)))
+			for i, expected := range test.expected {
+				assertAttr(t, state, "names."+strconv.Itoa(i), expected)
+			}
+			if state.ID == allID {
+				t.Error("expected the ID to depend on the applications")
+			}
+		})
+	}
+
+	_, diags := readDataSource(t, "scalingo_apps", meta, map[string]any{"name_regex": "api-("})
+	assertDiagContains(t, diags, "invalid regular expression")
+}
+
+func TestDataSourceApps_InvalidNameRegexRead(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	// The validation is skipped for values unknown at plan time, the read
+	// must not panic on them.
+	data := schema.TestResourceDataRaw(t, dataSourceScApps().Schema, map[string]any{"name_regex": "api-("})
+	diags := dataSourceScAppsRead(context.Background(), data, meta)
+	assertDiagContains(t, diags, "invalid name_regex: error parsing regexp")
+}
@@ -65,9 +65,9 @@ func dataSourceScContainerSize() *schema.Resource {
 }
 
 func dataSourceScContainerSizeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
-	containers, err := client.ContainerSizesList(ctx)
+	containers, err := client.catalog.ContainerSizes(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,26 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestDataSourceContainerSize(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	state := mustReadDataSource(t, "scalingo_container_size", meta, map[string]any{
+		"name": "XL",
+	})
+	if state.ID != "size-xl" {
+		t.Errorf("expected ID size-xl, got %s", state.ID)
+	}
+	assertAttr(t, state, "human_name", "Extra Large")
+	assertAttr(t, state, "memory", "2147483648")
+	assertAttr(t, state, "ordinal", "4")
+
+	_, diags := readDataSource(t, "scalingo_container_size", meta, map[string]any{
+		"name": "XXL",
+	})
+	if !diags.HasError() {
+		t.Error("expected an error for an unknown container size")
+	}
+}
@@ -0,0 +1,98 @@
+package scalingo
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceScCronTasks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScCronTasksRead,
+		Description: "Cron tasks of an application, as defined in the cron.json file of its deployed code, e.g. to check in a `check` block that the expected jobs are deployed",
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the targeted application",
+			},
+			"commands": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Commands of the jobs, in the order of the cron.json file",
+			},
+			"jobs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Jobs of the cron tasks, in the order of the cron.json file",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"command": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Command run by the job, including its schedule",
+						},
+						"size": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Size of the containers running the job, empty for the default size",
+						},
+						"last_execution_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date of the last execution of the job (RFC 3339), empty if it has never been run",
+						},
+						"next_execution_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date of the next execution of the job (RFC 3339)",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceScCronTasksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+
+	cronTasks, err := client.CronTasksGet(ctx, appID)
+	if isNotFoundError(err) {
+		// The API answers not found if the application has no cron.json file.
+		_, err = client.AppsShow(ctx, appID)
+		if err != nil {
+			return diag.Errorf("fetch application %v: %v", appID, err)
+		}
+	} else if err != nil {
+		return diag.Errorf("get cron tasks: %v", err)
+	}
+
+	commands := make([]string, 0, len(cronTasks.Jobs))
+	jobs := make([]interface{}, 0, len(cronTasks.Jobs))
+	for _, job := range cronTasks.Jobs {
+		commands = append(commands, job.Command)
+		jobs = append(jobs, map[string]interface{}{
+			"command":             job.Command,
+			"size":                job.Size,
+			"last_execution_date": formatTime(&job.LastExecutionDate),
+			"next_execution_date": formatTime(&job.NextExecutionDate),
+		})
+	}
+
+	err = SetAll(d, map[string]interface{}{
+		"commands": commands,
+		"jobs":     jobs,
+	})
+	if err != nil {
+		return diag.Errorf("store cron tasks: %v", err)
+	}
+	d.SetId(appID)
+
+	return nil
+}
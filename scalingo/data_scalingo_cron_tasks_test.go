@@ -0,0 +1,46 @@
+package scalingo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestDataSourceCronTasks(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	// Without a cron.json file, the application has no job.
+	state := mustReadDataSource(t, "scalingo_cron_tasks", meta, map[string]any{"app": app.ID})
+	if state.ID != app.ID {
+		t.Errorf("expected ID %s, got %s", app.ID, state.ID)
+	}
+	assertAttr(t, state, "jobs.#", "0")
+
+	app.cronTasks = &scalingo.CronTasks{Jobs: []scalingo.Job{
+		{
+			Command:           "0 3 * * * bundle exec rake cleanup",
+			Size:              "L",
+			LastExecutionDate: time.Date(2024, 3, 14, 3, 0, 0, 0, time.UTC),
+			NextExecutionDate: time.Date(2024, 3, 15, 3, 0, 0, 0, time.UTC),
+		},
+		{
+			Command:           "*/10 * * * * bundle exec rake refresh",
+			NextExecutionDate: time.Date(2024, 3, 14, 3, 10, 0, 0, time.UTC),
+		},
+	}}
+	state = mustReadDataSource(t, "scalingo_cron_tasks", meta, map[string]any{"app": app.ID})
+	assertAttr(t, state, "commands.#", "2")
+	assertAttr(t, state, "commands.1", "*/10 * * * * bundle exec rake refresh")
+	assertAttr(t, state, "jobs.#", "2")
+	assertAttr(t, state, "jobs.0.command", "0 3 * * * bundle exec rake cleanup")
+	assertAttr(t, state, "jobs.0.size", "L")
+	assertAttr(t, state, "jobs.0.last_execution_date", "2024-03-14T03:00:00Z")
+	assertAttr(t, state, "jobs.0.next_execution_date", "2024-03-15T03:00:00Z")
+	assertAttr(t, state, "jobs.1.size", "")
+	assertAttr(t, state, "jobs.1.last_execution_date", "")
+
+	_, diags := readDataSource(t, "scalingo_cron_tasks", meta, map[string]any{"app": "unknown"})
+	assertDiagContains(t, diags, "fetch application unknown")
+}
@@ -35,8 +35,8 @@ func dataSourceScDatabaseFirewallManagedRange() *schema.Resource {
 }
 
 func dataSourceScDatabaseFirewallManagedRangeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
-	previewClient := scalingo.NewPreviewClient(client)
+	client, _ := meta.(*providerMeta)
+	previewClient := scalingo.NewPreviewClient(client.Client)
 
 	databaseID, _ := d.Get("database_id").(string)
 	name, _ := d.Get("name").(string)
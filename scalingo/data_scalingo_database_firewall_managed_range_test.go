@@ -0,0 +1,28 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestDataSourceDatabaseFirewallManagedRange(t *testing.T) {
+	_, meta := newTestProvider(t)
+	database := mustApplyResource(t, "scalingo_database", meta, nil, map[string]any{
+		"name":       "my-db",
+		"technology": "postgresql-ng",
+		"plan":       "postgresql-ng-starter-4096",
+	})
+
+	state := mustReadDataSource(t, "scalingo_database_firewall_managed_range", meta, map[string]any{
+		"database_id": database.ID,
+		"name":        "Scalingo",
+	})
+	if state.ID != "mr-scalingo" {
+		t.Errorf("expected ID mr-scalingo, got %s", state.ID)
+	}
+
+	_, diags := readDataSource(t, "scalingo_database_firewall_managed_range", meta, map[string]any{
+		"database_id": database.ID,
+		"name":        "Unknown",
+	})
+	assertDiagContains(t, diags, "managed range 'Unknown' not found")
+}
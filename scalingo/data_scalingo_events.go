@@ -0,0 +1,459 @@
+package scalingo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+	"github.com/Scalingo/go-utils/pagination"
+)
+
+// defaultEventsMaxPages is the default number of pages of events fetched by
+// the scalingo_events data source.
+const defaultEventsMaxPages = 20
+
+func dataSourceScEvents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceScEventsRead,
+		Description: "Events of an application, or of all the applications of the account, optionally filtered by type and date, e.g. to find the last deployment or the last scaling of an application. " +
+			"Setting `after` is recommended: the events are fetched page by page until it is reached, up to `max_pages`",
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the targeted application, the events of all the applications of the account if unset",
+			},
+			"types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Only keep the events of these types, e.g. `deployment` or `scale`",
+			},
+			"after": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateRFC3339,
+				Description:      "Only keep the events created after this date (RFC 3339), e.g. `2024-01-01T00:00:00Z`",
+			},
+			"before": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validateRFC3339,
+				Description:      "Only keep the events created before this date (RFC 3339)",
+			},
+			"max_pages": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          defaultEventsMaxPages,
+				ValidateDiagFunc: validateMaxPages,
+				Description:      fmt.Sprintf("Maximum number of pages of %d events fetched, from the most recent ones, a warning is reported if older events are left out (default: `%d`)", PageSize, defaultEventsMaxPages),
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Events, from the most recent to the oldest",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the event",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the event, e.g. `deployment`",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Date of the event (RFC 3339)",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Human readable description of the event",
+						},
+						"app_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the application of the event",
+						},
+						"app_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the application of the event",
+						},
+						"project_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the project of the application of the event",
+						},
+						"user_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the user who triggered the event",
+						},
+						"user_username": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Username of the user who triggered the event",
+						},
+						"user_email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Email of the user who triggered the event",
+						},
+						"deployment": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Details of a `deployment` event, empty for the other types",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "ID of the deployment",
+									},
+									"git_ref": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Git reference of the deployed code",
+									},
+									"pusher": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "User who pushed the deployed code",
+									},
+									"status": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Status of the deployment, e.g. `success`",
+									},
+									"duration": {
+										Type:        schema.TypeInt,
+										Computed:    true,
+										Description: "Duration of the deployment, in seconds",
+									},
+								},
+							},
+						},
+						"scale": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Details of a `scale` event, empty for the other types",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"containers": {
+										Type:        schema.TypeMap,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "Formation after the scaling, by container type, e.g. `2:M`",
+									},
+									"previous_containers": {
+										Type:        schema.TypeMap,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "Formation before the scaling, by container type",
+									},
+								},
+							},
+						},
+						"restart": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Details of a `restart` event, empty for the other types",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"scope": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "Restarted container types, empty if all of them were restarted",
+									},
+									"reason": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Reason of the restart, e.g. `user_restart`",
+									},
+									"addon_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Name of the addon which triggered the restart, if any",
+									},
+								},
+							},
+						},
+						"variables": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Details of a `new_variable`, `edit_variable`, `edit_variables` or `delete_variable` event, empty for the other types. The values of the variables are not exposed",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"added": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "Names of the added variables",
+									},
+									"modified": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "Names of the modified variables",
+									},
+									"removed": {
+										Type:        schema.TypeList,
+										Computed:    true,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+										Description: "Names of the removed variables",
+									},
+								},
+							},
+						},
+						"addon": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Details of a `new_addon`, `upgrade_addon` or `delete_addon` event, empty for the other types",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"resource_id": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Resource ID of the addon",
+									},
+									"addon_provider": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Name of the addon provider",
+									},
+									"plan": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Plan of the addon, the new one for an upgrade",
+									},
+									"previous_plan": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Plan of the addon before an upgrade, empty for the other types",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceScEventsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+	types := map[scalingo.EventTypeName]bool{}
+	for _, eventType := range d.Get("types").([]interface{}) {
+		name, _ := eventType.(string)
+		types[scalingo.EventTypeName(name)] = true
+	}
+	var afterTime, beforeTime time.Time
+	if after, _ := d.Get("after").(string); after != "" {
+		var err error
+		afterTime, err = time.Parse(time.RFC3339, after)
+		if err != nil {
+			return diag.Errorf("parse after: %v", err)
+		}
+	}
+	if before, _ := d.Get("before").(string); before != "" {
+		var err error
+		beforeTime, err = time.Parse(time.RFC3339, before)
+		if err != nil {
+			return diag.Errorf("parse before: %v", err)
+		}
+	}
+	maxPages, _ := d.Get("max_pages").(int)
+
+	var (
+		events    scalingo.Events
+		diags     diag.Diagnostics
+		truncated bool
+	)
+	for currentPage := 1; ; currentPage++ {
+		var (
+			pageEvents scalingo.Events
+			pageMeta   pagination.Meta
+			err        error
+		)
+		if appID != "" {
+			pageEvents, pageMeta, err = client.EventsList(ctx, appID, pagination.NewRequest(currentPage, PageSize))
+		} else {
+			pageEvents, pageMeta, err = client.UserEventsList(ctx, pagination.NewRequest(currentPage, PageSize))
+		}
+		if err != nil {
+			return diag.Errorf("list events: %v", err)
+		}
+		events = append(events, pageEvents...)
+
+		// The events are sorted from the most recent to the oldest: the next
+		// pages are older than the after filter once it is reached.
+		if len(pageEvents) == 0 || currentPage >= pageMeta.TotalPages {
+			break
+		}
+		if !afterTime.IsZero() && !pageEvents[len(pageEvents)-1].GetEvent().CreatedAt.After(afterTime) {
+			break
+		}
+		if currentPage >= maxPages {
+			truncated = true
+			break
+		}
+	}
+	if truncated {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Only the %d most recent pages of events have been fetched", maxPages),
+			Detail:   "The older events are left out, set `after` or increase `max_pages` to get them.",
+		})
+	}
+
+	events = keepIf(events, func(event scalingo.DetailedEvent) bool {
+		ev := event.GetEvent()
+		if len(types) > 0 && !types[ev.Type] {
+			return false
+		}
+		return isInTimeRange(afterTime, beforeTime, ev.CreatedAt)
+	})
+
+	ids := make([]string, 0, len(events))
+	flattenedEvents := make([]interface{}, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.GetEvent().ID)
+		flattenedEvents = append(flattenedEvents, flattenEvent(event))
+	}
+
+	err := d.Set("events", flattenedEvents)
+	if err != nil {
+		return diag.Errorf("store events: %v", err)
+	}
+
+	// The ID changes with the list of events.
+	hash := sha256.Sum256([]byte(appID + ":" + strings.Join(ids, ",")))
+	d.SetId(hex.EncodeToString(hash[:]))
+
+	return diags
+}
+
+// flattenEvent returns the attributes of an event, with the details of the
+// types decoded by the go-scalingo specializations.
+func flattenEvent(event scalingo.DetailedEvent) map[string]interface{} {
+	ev := event.GetEvent()
+	attributes := map[string]interface{}{
+		"id":            ev.ID,
+		"type":          string(ev.Type),
+		"created_at":    formatTime(&ev.CreatedAt),
+		"description":   event.String(),
+		"app_id":        ev.AppID,
+		"app_name":      ev.AppName,
+		"project_id":    ev.ProjectID,
+		"user_id":       ev.User.ID,
+		"user_username": ev.User.Username,
+		"user_email":    ev.User.Email,
+	}
+
+	switch typedEvent := event.(type) {
+	case *scalingo.EventDeploymentType:
+		attributes["deployment"] = []interface{}{map[string]interface{}{
+			"id":       typedEvent.TypeData.DeploymentID,
+			"git_ref":  typedEvent.TypeData.GitRef,
+			"pusher":   typedEvent.TypeData.Pusher,
+			"status":   typedEvent.TypeData.Status,
+			"duration": typedEvent.TypeData.Duration,
+		}}
+	case *scalingo.EventScaleType:
+		attributes["scale"] = []interface{}{map[string]interface{}{
+			"containers":          typedEvent.TypeData.Containers,
+			"previous_containers": typedEvent.TypeData.PreviousContainers,
+		}}
+	case *scalingo.EventRestartType:
+		attributes["restart"] = []interface{}{map[string]interface{}{
+			"scope":      typedEvent.TypeData.Scope,
+			"reason":     string(typedEvent.TypeData.Reason),
+			"addon_name": typedEvent.TypeData.AddonName,
+		}}
+	case *scalingo.EventNewVariableType:
+		attributes["variables"] = flattenEventVariables(scalingo.EventVariables{typedEvent.TypeData.EventVariable}, nil, nil)
+	case *scalingo.EventEditVariableType:
+		attributes["variables"] = flattenEventVariables(nil, scalingo.EventVariables{typedEvent.TypeData.EventVariable}, nil)
+	case *scalingo.EventEditVariablesType:
+		attributes["variables"] = flattenEventVariables(typedEvent.TypeData.NewVars, typedEvent.TypeData.UpdatedVars, typedEvent.TypeData.DeletedVars)
+	case *scalingo.EventDeleteVariableType:
+		attributes["variables"] = flattenEventVariables(nil, nil, scalingo.EventVariables{typedEvent.TypeData.EventVariable})
+	case *scalingo.EventNewAddonType:
+		attributes["addon"] = flattenEventAddon(typedEvent.TypeData.EventAddon, typedEvent.TypeData.PlanName, "")
+	case *scalingo.EventUpgradeAddonType:
+		attributes["addon"] = flattenEventAddon(typedEvent.TypeData.EventAddon, typedEvent.TypeData.NewPlanName, typedEvent.TypeData.OldPlanName)
+	case *scalingo.EventDeleteAddonType:
+		attributes["addon"] = flattenEventAddon(typedEvent.TypeData.EventAddon, typedEvent.TypeData.PlanName, "")
+	}
+
+	return attributes
+}
+
+// flattenEventVariables only keeps the names of the variables: their values
+// are secrets.
+func flattenEventVariables(added, modified, removed scalingo.EventVariables) []interface{} {
+	names := func(variables scalingo.EventVariables) []string {
+		names := make([]string, 0, len(variables))
+		for _, variable := range variables {
+			names = append(names, variable.Name)
+		}
+		return names
+	}
+	return []interface{}{map[string]interface{}{
+		"added":    names(added),
+		"modified": names(modified),
+		"removed":  names(removed),
+	}}
+}
+
+func flattenEventAddon(addon scalingo.EventAddon, plan, previousPlan string) []interface{} {
+	return []interface{}{map[string]interface{}{
+		"resource_id":    addon.ResourceID,
+		"addon_provider": addon.AddonProviderName,
+		"plan":           plan,
+		"previous_plan":  previousPlan,
+	}}
+}
+
+func validateMaxPages(value interface{}, path cty.Path) diag.Diagnostics {
+	maxPages, _ := value.(int)
+	if maxPages < 1 {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("invalid max_pages %d, it must be at least 1", maxPages),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+func validateRFC3339(value interface{}, path cty.Path) diag.Diagnostics {
+	date, _ := value.(string)
+	_, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("invalid date %q, expected RFC 3339 format: %v", date, err),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
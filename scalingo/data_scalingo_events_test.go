@@ -0,0 +1,116 @@
+package scalingo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestDataSourceEvents(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	other := f.createApp(scalingo.AppsCreateOpts{Name: "other-app"})
+
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	// More events than a page of the API.
+	for i := range 55 {
+		f.addEvent(app, scalingo.EventScale, start.Add(time.Duration(i)*time.Hour), scalingo.EventScaleTypeData{
+			PreviousContainers: map[string]string{"web": "1:M"},
+			Containers:         map[string]string{"web": "2:M"},
+		})
+	}
+	f.addEvent(app, scalingo.EventDeployment, start.Add(60*time.Hour), scalingo.EventDeploymentTypeData{
+		DeploymentID: "deployment-1", Pusher: "john", GitRef: "v1.2.0", Status: "success", Duration: 42,
+	})
+	f.addEvent(app, scalingo.EventRestart, start.Add(61*time.Hour), scalingo.EventRestartTypeData{
+		Scope: []string{"web"}, Reason: scalingo.ContainerRestartReasonUserRestart,
+	})
+	f.addEvent(app, scalingo.EventEditVariables, start.Add(62*time.Hour), scalingo.EventEditVariablesTypeData{
+		NewVars:     scalingo.EventVariables{{Name: "API_KEY", Value: "secret"}},
+		UpdatedVars: scalingo.EventVariables{{Name: "WORKERS", Value: "4"}},
+	})
+	f.addEvent(app, scalingo.EventUpgradeAddon, start.Add(63*time.Hour), scalingo.EventUpgradeAddonTypeData{
+		EventAddon:  scalingo.EventAddon{ResourceID: "my-app-1234", AddonProviderName: "PostgreSQL"},
+		OldPlanName: "postgresql-starter-512",
+		NewPlanName: "postgresql-starter-1024",
+	})
+	f.addEvent(other, scalingo.EventNewVariable, start.Add(64*time.Hour), scalingo.EventNewVariableTypeData{
+		EventVariable: scalingo.EventVariable{Name: "DEBUG", Value: "true"},
+	})
+
+	state := mustReadDataSource(t, "scalingo_events", meta, map[string]any{"app": app.ID})
+	assertAttr(t, state, "events.#", "59")
+	if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID+"/events"); count != 2 {
+		t.Errorf("expected the events to be listed page by page, got %d requests", count)
+	}
+	assertAttr(t, state, "events.0.type", "upgrade_addon")
+	assertAttr(t, state, "events.0.app_name", "my-app")
+	assertAttr(t, state, "events.0.user_username", fakeOwner.Username)
+	assertAttr(t, state, "events.0.created_at", "2024-03-03T15:00:00Z")
+	assertAttr(t, state, "events.0.addon.0.resource_id", "my-app-1234")
+	assertAttr(t, state, "events.0.addon.0.addon_provider", "PostgreSQL")
+	assertAttr(t, state, "events.0.addon.0.plan", "postgresql-starter-1024")
+	assertAttr(t, state, "events.0.addon.0.previous_plan", "postgresql-starter-512")
+	assertAttr(t, state, "events.0.deployment.#", "0")
+	assertAttr(t, state, "events.1.variables.0.added.#", "1")
+	assertAttr(t, state, "events.1.variables.0.added.0", "API_KEY")
+	assertAttr(t, state, "events.1.variables.0.modified.0", "WORKERS")
+	assertAttr(t, state, "events.1.variables.0.removed.#", "0")
+	assertAttr(t, state, "events.2.restart.0.scope.0", "web")
+	assertAttr(t, state, "events.2.restart.0.reason", "user_restart")
+	assertAttr(t, state, "events.3.description", "deployment of v1.2.0 (success)")
+	assertAttr(t, state, "events.3.deployment.0.id", "deployment-1")
+	assertAttr(t, state, "events.3.deployment.0.git_ref", "v1.2.0")
+	assertAttr(t, state, "events.3.deployment.0.duration", "42")
+	assertAttr(t, state, "events.4.scale.0.containers.web", "2:M")
+	assertAttr(t, state, "events.4.scale.0.previous_containers.web", "1:M")
+	assertAttr(t, state, "events.58.created_at", "2024-03-01T00:00:00Z")
+
+	// The next pages aren't fetched once the after filter is reached.
+	state = mustReadDataSource(t, "scalingo_events", meta, map[string]any{
+		"app":    app.ID,
+		"types":  []any{"scale", "deployment"},
+		"after":  "2024-03-02T12:00:00Z",
+		"before": "2024-03-03T13:00:00Z",
+	})
+	assertAttr(t, state, "events.#", "19")
+	assertAttr(t, state, "events.0.type", "deployment")
+	assertAttr(t, state, "events.1.created_at", "2024-03-03T06:00:00Z")
+	assertAttr(t, state, "events.18.created_at", "2024-03-02T13:00:00Z")
+	if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID+"/events"); count != 3 {
+		t.Errorf("expected a single page of events to be listed, got %d more requests", count-2)
+	}
+
+	// Without application, the events of all the applications are listed.
+	state = mustReadDataSource(t, "scalingo_events", meta, map[string]any{
+		"types": []any{"new_variable", "edit_variables"},
+	})
+	assertAttr(t, state, "events.#", "2")
+	assertAttr(t, state, "events.0.app_id", other.ID)
+	assertAttr(t, state, "events.0.variables.0.added.0", "DEBUG")
+	assertAttr(t, state, "events.1.app_id", app.ID)
+
+	// The pages fetched are capped.
+	state, diags := readDataSource(t, "scalingo_events", meta, map[string]any{"app": app.ID, "max_pages": 1})
+	if diags.HasError() || len(diags) != 1 || diags[0].Summary != "Only the 1 most recent pages of events have been fetched" {
+		t.Errorf("expected a warning about the events left out, got %v", diags)
+	}
+	assertAttr(t, state, "events.#", "50")
+	assertAttr(t, state, "events.49.created_at", "2024-03-01T09:00:00Z")
+
+	_, diags = readDataSource(t, "scalingo_events", meta, map[string]any{"app": app.ID, "max_pages": 0})
+	assertDiagContains(t, diags, "invalid max_pages 0, it must be at least 1")
+
+	_, diags = readDataSource(t, "scalingo_events", meta, map[string]any{"app": app.ID, "after": "2024-03-01"})
+	assertDiagContains(t, diags, `invalid date "2024-03-01", expected RFC 3339 format`)
+
+	// The validation is skipped for values unknown at plan time.
+	data := schema.TestResourceDataRaw(t, dataSourceScEvents().Schema, map[string]any{"app": app.ID, "before": "2024-03-01"})
+	diags = dataSourceScEventsRead(context.Background(), data, meta)
+	assertDiagContains(t, diags, "parse before: ")
+}
@@ -182,7 +182,7 @@ func dataSourceScInvoiceRead(ctx context.Context, d *schema.ResourceData, meta i
 		err        error
 	)
 
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	// Handling date filters
 	beforeTimeStr, _ := d.Get("before").(string)
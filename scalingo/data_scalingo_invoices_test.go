@@ -0,0 +1,26 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestDataSourceInvoices(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	state := mustReadDataSource(t, "scalingo_invoices", meta, map[string]any{})
+	assertAttr(t, state, "invoices.#", "3")
+
+	state = mustReadDataSource(t, "scalingo_invoices", meta, map[string]any{
+		"after":  "2024-01-15",
+		"before": "2024-02-15",
+	})
+	assertAttr(t, state, "invoices.#", "1")
+	assertAttr(t, state, "invoices.0.id", "inv-2")
+	assertAttr(t, state, "invoices.0.invoice_number", "2024-0002")
+	assertAttr(t, state, "invoices.0.billing_month", "2024-02-01T00:00:00Z")
+
+	_, diags := readDataSource(t, "scalingo_invoices", meta, map[string]any{
+		"before": "March 2024",
+	})
+	assertDiagContains(t, diags, "fail to parse before")
+}
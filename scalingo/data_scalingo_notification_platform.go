@@ -48,7 +48,7 @@ func dataSourceScNotificationPlatform() *schema.Resource {
 
 // dataSourceScNotificationPlatformRead performs the Scalingo API lookup
 func dataSourceScNotificationPlatformRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	platforms, err := client.NotificationPlatformsList(ctx)
 	if err != nil {
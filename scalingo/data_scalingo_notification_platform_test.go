@@ -0,0 +1,25 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestDataSourceNotificationPlatform(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	state := mustReadDataSource(t, "scalingo_notification_platform", meta, map[string]any{
+		"name": "webhook",
+	})
+	if state.ID != "np-webhook" {
+		t.Errorf("expected ID np-webhook, got %s", state.ID)
+	}
+	assertAttr(t, state, "display_name", "Webhook")
+	assertAttr(t, state, "available_event_ids.#", "2")
+
+	_, diags := readDataSource(t, "scalingo_notification_platform", meta, map[string]any{
+		"name": "carrier-pigeon",
+	})
+	if !diags.HasError() {
+		t.Error("expected an error for an unknown notification platform")
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
-	"github.com/Scalingo/go-scalingo/v11"
 	"github.com/Scalingo/go-utils/pagination"
 )
 
@@ -47,7 +46,7 @@ func dataSourceScPrivateNetworkDomain() *schema.Resource {
 }
 
 func dataSourceScPrivateNetworkDomainsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, ok := d.Get("app").(string)
 	if !ok || appID == "" {
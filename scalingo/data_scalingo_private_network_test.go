@@ -9,6 +9,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Scalingo/go-scalingo/v11"
 )
 
 func testAccPrivateNetworkAppID(t *testing.T) string {
@@ -93,3 +95,25 @@ func testAccCheckDomainsCountMax(resourceName string, max int) resource.TestChec
 		return nil
 	}
 }
+
+func TestDataSourcePrivateNetworkDomains(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustReadDataSource(t, "scalingo_private_network_domain", meta, map[string]any{
+		"app": app.ID,
+	})
+	if state.ID != app.ID {
+		t.Errorf("expected ID %s, got %s", app.ID, state.ID)
+	}
+	assertAttr(t, state, "domains.#", "2")
+	assertAttr(t, state, "domains.0", "web-1.my-app.pn.fake")
+
+	state = mustReadDataSource(t, "scalingo_private_network_domain", meta, map[string]any{
+		"app":       app.ID,
+		"page":      2,
+		"page_size": 1,
+	})
+	assertAttr(t, state, "domains.#", "1")
+	assertAttr(t, state, "domains.0", "web-2.my-app.pn.fake")
+}
@@ -7,8 +7,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
-	"github.com/Scalingo/go-scalingo/v11"
 )
 
 func dataSourceScProject() *schema.Resource {
@@ -37,7 +35,7 @@ func dataSourceScProject() *schema.Resource {
 }
 
 func dataSourceScProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	projectID, ok := d.Get("id").(string)
 	if !ok || projectID == "" {
@@ -0,0 +1,40 @@
+package scalingo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestDataSourceProject(t *testing.T) {
+	_, meta := newTestProvider(t)
+	project := mustApplyResource(t, "scalingo_project", meta, nil, map[string]any{
+		"name": "my-project",
+	})
+
+	// The project is looked up with its computed "id" attribute, which can't go
+	// through the configuration validation: the read function is called directly.
+	r := testDataSource(t, "scalingo_project")
+	d := r.Data(&terraform.InstanceState{Attributes: map[string]string{"id": project.ID}})
+	diags := r.ReadContext(context.Background(), d, meta)
+	if diags.HasError() {
+		t.Fatalf("read scalingo_project: %v", diags)
+	}
+	if d.Id() != project.ID {
+		t.Errorf("expected ID %s, got %s", project.ID, d.Id())
+	}
+	if name := d.Get("name"); name != "my-project" {
+		t.Errorf("expected name my-project, got %v", name)
+	}
+
+	d = r.Data(&terraform.InstanceState{Attributes: map[string]string{"id": "unknown"}})
+	diags = r.ReadContext(context.Background(), d, meta)
+	if !diags.HasError() {
+		t.Error("expected an error for an unknown project")
+	}
+
+	d = r.Data(&terraform.InstanceState{})
+	diags = r.ReadContext(context.Background(), d, meta)
+	assertDiagContains(t, diags, "id attribute is mandatory")
+}
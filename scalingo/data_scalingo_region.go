@@ -51,7 +51,7 @@ func dataSourceScRegion() *schema.Resource {
 }
 
 func dataSourceScRegionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	regions, err := client.RegionsList(ctx)
 	if err != nil {
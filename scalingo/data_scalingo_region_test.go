@@ -0,0 +1,25 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestDataSourceRegion(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	state := mustReadDataSource(t, "scalingo_region", meta, map[string]any{
+		"name": f.region,
+	})
+	if state.ID != f.region {
+		t.Errorf("expected ID %s, got %s", f.region, state.ID)
+	}
+	assertAttr(t, state, "api", f.api.URL)
+	assertAttr(t, state, "database_api", f.db.URL)
+
+	_, diags := readDataSource(t, "scalingo_region", meta, map[string]any{
+		"name": "unknown",
+	})
+	if !diags.HasError() {
+		t.Error("expected an error for an unknown region")
+	}
+}
@@ -60,7 +60,7 @@ func dataSourceScScmIntegration() *schema.Resource {
 }
 
 func dataSourceScScmIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	scmType, _ := d.Get("scm_type").(string)
 	url, _ := d.Get("url").(string)
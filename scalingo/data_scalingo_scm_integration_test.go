@@ -0,0 +1,32 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestDataSourceScmIntegration(t *testing.T) {
+	_, meta := newTestProvider(t)
+	integration := mustApplyResource(t, "scalingo_scm_integration", meta, nil, map[string]any{
+		"scm_type":     "gitlab-self-hosted",
+		"url":          "https://gitlab.example.test",
+		"access_token": "glpat-secret",
+	})
+	mustApplyResource(t, "scalingo_scm_integration", meta, nil, map[string]any{
+		"scm_type":     "github-enterprise",
+		"url":          "https://github.example.test",
+		"access_token": "ghp-secret",
+	})
+
+	state := mustReadDataSource(t, "scalingo_scm_integration", meta, map[string]any{
+		"scm_type": "gitlab-self-hosted",
+	})
+	if state.ID != integration.ID {
+		t.Errorf("expected ID %s, got %s", integration.ID, state.ID)
+	}
+	assertAttr(t, state, "url", "https://gitlab.example.test")
+	assertAttr(t, state, "owner_id", fakeOwner.ID)
+
+	// Both integrations match without filter.
+	_, diags := readDataSource(t, "scalingo_scm_integration", meta, map[string]any{})
+	assertDiagContains(t, diags, "fail to find the selected integration")
+}
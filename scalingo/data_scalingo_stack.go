@@ -47,14 +47,14 @@ func dataSourceScStack() *schema.Resource {
 }
 
 func dataSourceScStackRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	name, ok := d.Get("name").(string)
 	if !ok || name == "" {
 		return diag.Errorf("name attribute is mandatory")
 	}
 
-	stacks, err := client.StacksList(ctx)
+	stacks, err := client.catalog.Stacks(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,30 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestDataSourceStack(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	state := mustReadDataSource(t, "scalingo_stack", meta, map[string]any{
+		"name": "scalingo-22",
+	})
+	if state.ID != "st-scalingo-22" {
+		t.Errorf("expected ID st-scalingo-22, got %s", state.ID)
+	}
+	assertAttr(t, state, "default", "true")
+	assertAttr(t, state, "base_image", "scalingo/scalingo-22")
+
+	state = mustReadDataSource(t, "scalingo_stack", meta, map[string]any{
+		"name": "scalingo-20",
+	})
+	assertAttr(t, state, "default", "false")
+
+	_, diags := readDataSource(t, "scalingo_stack", meta, map[string]any{
+		"name": "scalingo-14",
+	})
+	if !diags.HasError() {
+		t.Error("expected an error for an unknown stack")
+	}
+}
@@ -0,0 +1,2722 @@
+package scalingo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+// fakeRegionCounter makes every fake API instance expose its own region name:
+// go-scalingo caches regions process-wide by name.
+var fakeRegionCounter int64
+
+var fakeOwner = scalingo.Owner{
+	ID:       "us-fake",
+	Username: "fake-user",
+	Email:    "user@fake.test",
+}
+
+// fakeAPI is an in-memory implementation of the Scalingo, Auth and Database
+// APIs. It only implements the endpoints used by the provider and keeps just
+// enough state for the resources to be created, read, updated and deleted.
+//
+// Asynchronous objects (addons, databases, operations, database features) go
+// through a transitional status for pendingPolls reads before reaching their
+// final status, so that the wait loops of the provider are exercised.
+type fakeAPI struct {
+	t *testing.T
+
+	api    *httptest.Server
+	auth   *httptest.Server
+	db     *httptest.Server
+	stream *httptest.Server
+
+	region string
+
+	mu  sync.Mutex
+	seq int
+
+	// pendingPolls is the number of reads of an asynchronous object returning
+	// its transitional status.
+	pendingPolls int
+	// operationError makes every new operation end with this error when set.
+	operationError string
+	// deploymentStatus is the final status of new deployments, success when
+	// empty.
+	deploymentStatus scalingo.DeploymentStatus
+	// oneOffCrash makes the command of every new one-off container exit with
+	// a non-zero status.
+	oneOffCrash bool
+	// requests records "METHOD /path" of every request received.
+	requests []string
+	// failures are answered instead of the next matching requests.
+	failures []fakeFailure
+
+	apps            []*fakeApp
+	projects        []*scalingo.Project
+	keys            []*scalingo.Key
+	scmIntegrations []*scalingo.SCMIntegration
+	databases       map[string]*fakeDatabase
+	// sources are the uploaded source archives, by download URL.
+	sources map[string][]byte
+	// privateRepos are the "owner/repo" SCM repositories, served under /scm,
+	// whose archives can't be downloaded without credentials.
+	privateRepos map[string]bool
+
+	addonProviders        []*scalingo.AddonProvider
+	containerSizes        []scalingo.ContainerSize
+	stacks                []fakeStack
+	notificationPlatforms []*scalingo.NotificationPlatform
+	eventTypes            []scalingo.EventType
+	invoices              []fakeInvoice
+	managedRanges         []scalingo.FirewallManagedRange
+}
+
+// fakeFailure is a transient error answered by the fake API.
+type fakeFailure struct {
+	method     string
+	path       string
+	status     int
+	retryAfter string
+}
+
+type fakeApp struct {
+	scalingo.App
+
+	variables             scalingo.Variables
+	containers            []scalingo.ContainerType
+	addons                []*fakeAddon
+	domains               []*scalingo.Domain
+	alerts                []*scalingo.Alert
+	autoscalers           []*scalingo.Autoscaler
+	collaborators         []*scalingo.Collaborator
+	logDrains             []scalingo.LogDrain
+	notifiers             []*scalingo.Notifier
+	scmRepoLink           *scalingo.SCMRepoLink
+	operations            []*fakeOperation
+	privateNetworkDomains []string
+	restarts              []scalingo.AppsRestartParams
+	deployments           []*fakeDeployment
+	// containerStates overrides the state of the containers of a type,
+	// running when unset.
+	containerStates map[string]string
+	// bootingPolls is the number of reads of the containers returning them
+	// as booting after a scaling.
+	bootingPolls int
+	oneOffs      []*fakeOneOff
+	// cronTasks are the cron tasks of the deployed cron.json file, the
+	// application has no cron.json file if nil.
+	cronTasks *scalingo.CronTasks
+	// events are sorted from the most recent to the oldest, as answered by
+	// the API.
+	events []*scalingo.Event
+
+	// databaseNG is set when the application backs a Database NG.
+	databaseNG *scalingo.DatabaseNG
+}
+
+type fakeAddon struct {
+	scalingo.Addon
+
+	pending   int
+	logDrains []scalingo.LogDrain
+}
+
+// fakeDatabase is the Database API view of a database addon.
+type fakeDatabase struct {
+	scalingo.Database
+
+	pending       int
+	pendingStatus scalingo.DatabaseStatus
+	firewallRules []scalingo.FirewallRule
+}
+
+type fakeOperation struct {
+	scalingo.Operation
+
+	pending     int
+	finalStatus scalingo.OperationStatus
+	finalError  string
+}
+
+// fakeOneOff is a one-off container, listed as running for pending reads of
+// the containers. It then disappears, after being listed once as crashed if
+// its command failed.
+type fakeOneOff struct {
+	scalingo.Container
+
+	env     map[string]string
+	pending int
+	crashed bool
+	removed bool
+	output  []string
+}
+
+type fakeDeployment struct {
+	scalingo.Deployment
+
+	pending     int
+	finalStatus scalingo.DeploymentStatus
+	output      []string
+}
+
+// fakeStack is serialized by hand: the deprecation date is expected as
+// "2006-01-02" which is not the format produced by time.Time.
+type fakeStack struct {
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	Description  string  `json:"description"`
+	BaseImage    string  `json:"base_image"`
+	Default      bool    `json:"default"`
+	DeprecatedAt *string `json:"deprecated_at"`
+}
+
+// fakeInvoice is serialized by hand for the same reason as fakeStack.
+type fakeInvoice struct {
+	ID                string `json:"id"`
+	TotalPrice        int    `json:"total_price"`
+	TotalPriceWithVat int    `json:"total_price_with_vat"`
+	BillingMonth      string `json:"billing_month"`
+	PdfURL            string `json:"pdf_url"`
+	InvoiceNumber     string `json:"invoice_number"`
+	State             string `json:"state"`
+	VatRate           int    `json:"vat_rate"`
+}
+
+func newFakeAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+
+	f := &fakeAPI{
+		t:            t,
+		region:       fmt.Sprintf("fake-%d", atomic.AddInt64(&fakeRegionCounter, 1)),
+		pendingPolls: 1,
+		databases:    map[string]*fakeDatabase{},
+		sources:      map[string][]byte{},
+	}
+	f.seedCatalog()
+
+	f.api = httptest.NewServer(f.handler(f.apiRoutes))
+	f.auth = httptest.NewServer(f.handler(f.authRoutes))
+	f.db = httptest.NewServer(f.handler(f.dbRoutes))
+	// The deployment stream is long-lived: it is not served by the handler
+	// which locks the state for the whole request.
+	f.stream = httptest.NewServer(http.HandlerFunc(f.handleDeploymentStream))
+	t.Cleanup(func() {
+		f.api.Close()
+		f.auth.Close()
+		f.db.Close()
+		f.stream.CloseClientConnections()
+		f.stream.Close()
+	})
+
+	return f
+}
+
+func (f *fakeAPI) seedCatalog() {
+	f.addonProviders = []*scalingo.AddonProvider{
+		{
+			ID:       "postgresql",
+			Name:     "PostgreSQL",
+			Category: scalingo.Category{ID: "cat-db", Name: "Databases"},
+			Plans: []scalingo.Plan{
+				{ID: "pl-pg-512", Name: "postgresql-starter-512", DisplayName: "Starter 512M"},
+				{ID: "pl-pg-1024", Name: "postgresql-starter-1024", DisplayName: "Starter 1G"},
+			},
+		},
+		{
+			ID:       "postgresql-ng",
+			Name:     "PostgreSQL NG",
+			Category: scalingo.Category{ID: "cat-db", Name: "Databases"},
+			Plans: []scalingo.Plan{
+				{ID: "pl-pgng-starter", Name: "postgresql-ng-starter-4096", DisplayName: "Starter 4G"},
+				{ID: "pl-pgng-business", Name: "postgresql-ng-business-4096", DisplayName: "Business 4G"},
+			},
+		},
+		{
+			ID:       "mailjet",
+			Name:     "Mailjet",
+			Category: scalingo.Category{ID: "cat-email", Name: "Email"},
+			Plans: []scalingo.Plan{
+				{ID: "pl-mj-free", Name: "free", DisplayName: "Free"},
+				{ID: "pl-mj-basic", Name: "basic", DisplayName: "Basic"},
+			},
+		},
+	}
+
+	f.containerSizes = []scalingo.ContainerSize{
+		{ID: "size-s", Name: "S", HumanName: "Small", HumanCPU: "shared", Memory: 268435456, Ordinal: 1},
+		{ID: "size-m", Name: "M", HumanName: "Medium", HumanCPU: "shared", Memory: 536870912, Ordinal: 2},
+		{ID: "size-l", Name: "L", HumanName: "Large", HumanCPU: "shared", Memory: 1073741824, Ordinal: 3},
+		{ID: "size-xl", Name: "XL", HumanName: "Extra Large", HumanCPU: "dedicated", Memory: 2147483648, Ordinal: 4},
+	}
+
+	deprecatedAt := "2025-01-01"
+	f.stacks = []fakeStack{
+		{ID: "st-scalingo-20", Name: "scalingo-20", Description: "Ubuntu 20.04", BaseImage: "scalingo/scalingo-20", DeprecatedAt: &deprecatedAt},
+		{ID: "st-scalingo-22", Name: "scalingo-22", Description: "Ubuntu 22.04", BaseImage: "scalingo/scalingo-22", Default: true},
+		{ID: "st-scalingo-24", Name: "scalingo-24", Description: "Ubuntu 24.04", BaseImage: "scalingo/scalingo-24"},
+	}
+
+	f.notificationPlatforms = []*scalingo.NotificationPlatform{
+		{ID: "np-webhook", Name: "webhook", DisplayName: "Webhook", AvailableEventIDs: []string{"et-deployment", "et-restart"}},
+		{ID: "np-email", Name: "email", DisplayName: "E-mail", AvailableEventIDs: []string{"et-deployment"}},
+		{ID: "np-slack", Name: "slack", DisplayName: "Slack", AvailableEventIDs: []string{"et-deployment"}},
+	}
+
+	f.eventTypes = []scalingo.EventType{
+		{ID: "et-deployment", Name: "deployment", DisplayName: "Deployment"},
+		{ID: "et-restart", Name: "restart", DisplayName: "Restart"},
+		{ID: "et-app-crashed", Name: "app_crashed", DisplayName: "Application crashed"},
+	}
+
+	f.invoices = []fakeInvoice{
+		{ID: "inv-1", TotalPrice: 1000, TotalPriceWithVat: 1200, BillingMonth: "2024-01-01", InvoiceNumber: "2024-0001", State: "paid", VatRate: 2000},
+		{ID: "inv-2", TotalPrice: 2000, TotalPriceWithVat: 2400, BillingMonth: "2024-02-01", InvoiceNumber: "2024-0002", State: "paid", VatRate: 2000},
+		{ID: "inv-3", TotalPrice: 3000, TotalPriceWithVat: 3600, BillingMonth: "2024-03-01", InvoiceNumber: "2024-0003", State: "new", VatRate: 2000},
+	}
+
+	f.managedRanges = []scalingo.FirewallManagedRange{
+		{ID: "mr-scalingo", Name: "Scalingo"},
+	}
+}
+
+func (f *fakeAPI) handler(routes func(mux *http.ServeMux)) http.Handler {
+	mux := http.NewServeMux()
+	routes(mux)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		for i, failure := range f.failures {
+			if failure.method == r.Method && failure.path == r.URL.Path {
+				f.failures = append(f.failures[:i], f.failures[i+1:]...)
+				if failure.retryAfter != "" {
+					w.Header().Set("Retry-After", failure.retryAfter)
+				}
+				writeJSON(w, failure.status, map[string]string{"error": "upstream provider returned an error, please retry later"})
+				return
+			}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// failNext makes the next request with the given method and path fail with
+// the given status. It can be called several times to fail several requests.
+func (f *fakeAPI) failNext(method, path string, status int, retryAfter string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures = append(f.failures, fakeFailure{method: method, path: path, status: status, retryAfter: retryAfter})
+}
+
+// requestCount returns the number of requests received with the given method
+// and path.
+func (f *fakeAPI) requestCount(method, path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, r := range f.requests {
+		if r == method+" "+path {
+			count++
+		}
+	}
+	return count
+}
+
+// lock gives tests an exclusive access to the fake state.
+func (f *fakeAPI) lock() func() {
+	f.mu.Lock()
+	return f.mu.Unlock
+}
+
+func (f *fakeAPI) nextID(prefix string) string {
+	f.seq++
+	return fmt.Sprintf("%s-%d", prefix, f.seq)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeNotFound(w http.ResponseWriter, resource string) {
+	writeJSON(w, http.StatusNotFound, map[string]string{"resource": resource, "error": "not found"})
+}
+
+func writeUnprocessable(w http.ResponseWriter, field, message string) {
+	writeJSON(w, http.StatusUnprocessableEntity, map[string]map[string][]string{"errors": {field: {message}}})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+// findApp looks an application up by ID or by name.
+func (f *fakeAPI) findApp(idOrName string) *fakeApp {
+	for _, app := range f.apps {
+		if app.ID == idOrName || app.Name == idOrName {
+			return app
+		}
+	}
+	return nil
+}
+
+// appFromRequest returns the application targeted by the {app} path value,
+// writing a 404 when it doesn't exist.
+func (f *fakeAPI) appFromRequest(w http.ResponseWriter, r *http.Request) *fakeApp {
+	app := f.findApp(r.PathValue("app"))
+	if app == nil {
+		writeNotFound(w, "app")
+	}
+	return app
+}
+
+func (f *fakeAPI) findAddonProvider(id string) *scalingo.AddonProvider {
+	for _, provider := range f.addonProviders {
+		if provider.ID == id {
+			return provider
+		}
+	}
+	return nil
+}
+
+func findPlan(provider *scalingo.AddonProvider, planID string) *scalingo.Plan {
+	for i := range provider.Plans {
+		if provider.Plans[i].ID == planID {
+			return &provider.Plans[i]
+		}
+	}
+	return nil
+}
+
+func (app *fakeApp) findAddon(id string) *fakeAddon {
+	for _, addon := range app.addons {
+		if addon.ID == id {
+			return addon
+		}
+	}
+	return nil
+}
+
+// newOperation registers an asynchronous operation on the application and
+// returns its URL, as sent in the Location header by the API.
+func (f *fakeAPI) newOperation(app *fakeApp, opType scalingo.OperationType) string {
+	op := &fakeOperation{
+		Operation: scalingo.Operation{
+			ID:        f.nextID("op"),
+			AppID:     app.ID,
+			CreatedAt: time.Now(),
+			Status:    scalingo.OperationStatusPending,
+			Type:      opType,
+		},
+		pending:     f.pendingPolls,
+		finalStatus: scalingo.OperationStatusDone,
+	}
+	if f.operationError != "" {
+		op.finalStatus = scalingo.OperationStatusError
+		op.finalError = f.operationError
+	}
+	app.operations = append(app.operations, op)
+	return f.api.URL + "/v1/apps/" + app.ID + "/operations/" + op.ID
+}
+
+// Auth API
+
+func (f *fakeAPI) authRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /v1/tokens/exchange", f.handleTokenExchange)
+	mux.HandleFunc("GET /v1/regions", f.handleRegionsList)
+	mux.HandleFunc("GET /v1/users/self", f.handleSelf)
+
+	mux.HandleFunc("GET /v1/keys", f.handleKeysList)
+	mux.HandleFunc("POST /v1/keys", f.handleKeysAdd)
+	mux.HandleFunc("DELETE /v1/keys/{id}", f.handleKeysDelete)
+
+	mux.HandleFunc("GET /v1/scm_integrations", f.handleSCMIntegrationsList)
+	mux.HandleFunc("POST /v1/scm_integrations", f.handleSCMIntegrationsCreate)
+	mux.HandleFunc("GET /v1/scm_integrations/{id}", f.handleSCMIntegrationsShow)
+	mux.HandleFunc("DELETE /v1/scm_integrations/{id}", f.handleSCMIntegrationsDelete)
+}
+
+// fakeJWT builds an unsigned token: go-scalingo only reads its expiration.
+func fakeJWT() string {
+	encode := func(v any) string {
+		b, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	header := encode(map[string]string{"alg": "HS256", "typ": "JWT"})
+	claims := encode(map[string]any{"exp": time.Now().Add(time.Hour).Unix()})
+	return header + "." + claims + "." + base64.RawURLEncoding.EncodeToString([]byte("signature"))
+}
+
+func (f *fakeAPI) handleTokenExchange(w http.ResponseWriter, r *http.Request) {
+	_, token, ok := r.BasicAuth()
+	if !ok || token == "" {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "missing token"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"token": fakeJWT()})
+}
+
+func (f *fakeAPI) handleRegionsList(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]scalingo.Region{"regions": {{
+		Name:        f.region,
+		DisplayName: "Fake region",
+		SSH:         "ssh." + f.region + ".fake:22",
+		API:         f.api.URL,
+		Dashboard:   "https://dashboard." + f.region + ".fake",
+		DatabaseAPI: f.db.URL,
+		Default:     true,
+	}}})
+}
+
+func (f *fakeAPI) handleSelf(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, scalingo.SelfResponse{User: &scalingo.User{
+		ID:       fakeOwner.ID,
+		Username: fakeOwner.Username,
+		Email:    fakeOwner.Email,
+	}})
+}
+
+func (f *fakeAPI) handleKeysList(w http.ResponseWriter, _ *http.Request) {
+	keys := []scalingo.Key{}
+	for _, key := range f.keys {
+		keys = append(keys, *key)
+	}
+	writeJSON(w, http.StatusOK, scalingo.KeysRes{Keys: keys})
+}
+
+func (f *fakeAPI) handleKeysAdd(w http.ResponseWriter, r *http.Request) {
+	var payload scalingo.KeyRes
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	key := payload.Key
+	key.ID = f.nextID("key")
+	f.keys = append(f.keys, &key)
+	writeJSON(w, http.StatusCreated, scalingo.KeyRes{Key: key})
+}
+
+func (f *fakeAPI) handleKeysDelete(w http.ResponseWriter, r *http.Request) {
+	for i, key := range f.keys {
+		if key.ID == r.PathValue("id") {
+			f.keys = append(f.keys[:i], f.keys[i+1:]...)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "key")
+}
+
+func (f *fakeAPI) handleSCMIntegrationsList(w http.ResponseWriter, _ *http.Request) {
+	integrations := []scalingo.SCMIntegration{}
+	for _, integration := range f.scmIntegrations {
+		integrations = append(integrations, *integration)
+	}
+	writeJSON(w, http.StatusOK, scalingo.SCMIntegrationsRes{SCMIntegrations: integrations})
+}
+
+func (f *fakeAPI) handleSCMIntegrationsCreate(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		SCMIntegration scalingo.SCMIntegrationParams `json:"scm_integration"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	integration := &scalingo.SCMIntegration{
+		ID:          f.nextID("scm"),
+		SCMType:     payload.SCMIntegration.SCMType,
+		URL:         payload.SCMIntegration.URL,
+		AccessToken: payload.SCMIntegration.AccessToken,
+		UID:         "12345",
+		Username:    "fake-scm-user",
+		Email:       "scm@fake.test",
+		AvatarURL:   payload.SCMIntegration.URL + "/avatar.png",
+		ProfileURL:  payload.SCMIntegration.URL + "/fake-scm-user",
+		CreatedAt:   time.Now(),
+		Owner:       fakeOwner,
+	}
+	f.scmIntegrations = append(f.scmIntegrations, integration)
+	writeJSON(w, http.StatusCreated, scalingo.SCMIntegrationRes{SCMIntegration: *integration})
+}
+
+func (f *fakeAPI) handleSCMIntegrationsShow(w http.ResponseWriter, r *http.Request) {
+	for _, integration := range f.scmIntegrations {
+		if integration.ID == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, scalingo.SCMIntegrationRes{SCMIntegration: *integration})
+			return
+		}
+	}
+	writeNotFound(w, "scm_integration")
+}
+
+func (f *fakeAPI) handleSCMIntegrationsDelete(w http.ResponseWriter, r *http.Request) {
+	for i, integration := range f.scmIntegrations {
+		if integration.ID == r.PathValue("id") {
+			f.scmIntegrations = append(f.scmIntegrations[:i], f.scmIntegrations[i+1:]...)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "scm_integration")
+}
+
+// Scalingo API
+
+func (f *fakeAPI) apiRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /v1/apps", f.handleAppsList)
+	mux.HandleFunc("POST /v1/apps", f.handleAppsCreate)
+	mux.HandleFunc("GET /v1/apps/{app}", f.handleAppsShow)
+	mux.HandleFunc("PUT /v1/apps/{app}", f.handleAppsUpdate)
+	mux.HandleFunc("PATCH /v1/apps/{app}", f.handleAppsPatch)
+	mux.HandleFunc("DELETE /v1/apps/{app}", f.handleAppsDestroy)
+	mux.HandleFunc("POST /v1/apps/{app}/rename", f.handleAppsRename)
+	mux.HandleFunc("POST /v1/apps/{app}/restart", f.handleAppsRestart)
+	mux.HandleFunc("POST /v1/apps/{app}/scale", f.handleAppsScale)
+	mux.HandleFunc("GET /v1/apps/{app}/containers", f.handleAppsContainerTypes)
+	mux.HandleFunc("GET /v1/apps/{app}/ps", f.handleAppsContainersPs)
+	mux.HandleFunc("GET /v1/apps/{app}/stats", f.handleAppsStats)
+	mux.HandleFunc("POST /v1/apps/{app}/run", f.handleRun)
+	mux.HandleFunc("GET /v1/apps/{app}/logs", f.handleLogsURL)
+	mux.HandleFunc("GET /v1/apps/{app}/cron_tasks", f.handleCronTasksGet)
+	mux.HandleFunc("GET /v1/apps/{app}/events", f.handleEventsList)
+	mux.HandleFunc("GET /logs/{app}", f.handleLogs)
+	mux.HandleFunc("GET /v1/apps/{app}/operations/{id}", f.handleOperationsShow)
+	mux.HandleFunc("GET /v1/apps/{app}/private_network_domain_names", f.handlePrivateNetworkDomainsList)
+
+	mux.HandleFunc("GET /v1/apps/{app}/deployments", f.handleDeploymentsList)
+	mux.HandleFunc("POST /v1/apps/{app}/deployments", f.handleDeploymentsCreate)
+	mux.HandleFunc("GET /v1/apps/{app}/deployments/{id}", f.handleDeploymentShow)
+	mux.HandleFunc("GET /v1/apps/{app}/deployments/{id}/output", f.handleDeploymentOutput)
+
+	mux.HandleFunc("GET /v1/apps/{app}/variables", f.handleVariablesList)
+	mux.HandleFunc("PUT /v1/apps/{app}/variables", f.handleVariablesMultipleSet)
+	mux.HandleFunc("POST /v1/apps/{app}/variables", f.handleVariableSet)
+	mux.HandleFunc("DELETE /v1/apps/{app}/variables/{id}", f.handleVariableUnset)
+
+	mux.HandleFunc("GET /v1/apps/{app}/addons", f.handleAddonsList)
+	mux.HandleFunc("POST /v1/apps/{app}/addons", f.handleAddonProvision)
+	mux.HandleFunc("GET /v1/apps/{app}/addons/{id}", f.handleAddonShow)
+	mux.HandleFunc("PATCH /v1/apps/{app}/addons/{id}", f.handleAddonUpgrade)
+	mux.HandleFunc("DELETE /v1/apps/{app}/addons/{id}", f.handleAddonDestroy)
+	mux.HandleFunc("POST /v1/apps/{app}/addons/{id}/token", f.handleAddonToken)
+	mux.HandleFunc("GET /v1/apps/{app}/addons/{id}/log_drains", f.handleLogDrainsList)
+	mux.HandleFunc("POST /v1/apps/{app}/addons/{id}/log_drains", f.handleLogDrainAdd)
+	mux.HandleFunc("DELETE /v1/apps/{app}/addons/{id}/log_drains", f.handleLogDrainRemove)
+
+	mux.HandleFunc("GET /v1/apps/{app}/log_drains", f.handleLogDrainsList)
+	mux.HandleFunc("POST /v1/apps/{app}/log_drains", f.handleLogDrainAdd)
+	mux.HandleFunc("DELETE /v1/apps/{app}/log_drains", f.handleLogDrainRemove)
+
+	mux.HandleFunc("GET /v1/apps/{app}/domains", f.handleDomainsList)
+	mux.HandleFunc("POST /v1/apps/{app}/domains", f.handleDomainsAdd)
+	mux.HandleFunc("GET /v1/apps/{app}/domains/{id}", f.handleDomainsShow)
+	mux.HandleFunc("PATCH /v1/apps/{app}/domains/{id}", f.handleDomainsUpdate)
+	mux.HandleFunc("DELETE /v1/apps/{app}/domains/{id}", f.handleDomainsRemove)
+
+	mux.HandleFunc("GET /v1/apps/{app}/alerts", f.handleAlertsList)
+	mux.HandleFunc("POST /v1/apps/{app}/alerts", f.handleAlertAdd)
+	mux.HandleFunc("GET /v1/apps/{app}/alerts/{id}", f.handleAlertShow)
+	mux.HandleFunc("PATCH /v1/apps/{app}/alerts/{id}", f.handleAlertUpdate)
+	mux.HandleFunc("DELETE /v1/apps/{app}/alerts/{id}", f.handleAlertRemove)
+
+	mux.HandleFunc("GET /v1/apps/{app}/autoscalers", f.handleAutoscalersList)
+	mux.HandleFunc("POST /v1/apps/{app}/autoscalers", f.handleAutoscalerAdd)
+	mux.HandleFunc("GET /v1/apps/{app}/autoscalers/{id}", f.handleAutoscalerShow)
+	mux.HandleFunc("PATCH /v1/apps/{app}/autoscalers/{id}", f.handleAutoscalerUpdate)
+	mux.HandleFunc("DELETE /v1/apps/{app}/autoscalers/{id}", f.handleAutoscalerRemove)
+
+	mux.HandleFunc("GET /v1/apps/{app}/collaborators", f.handleCollaboratorsList)
+	mux.HandleFunc("POST /v1/apps/{app}/collaborators", f.handleCollaboratorAdd)
+	mux.HandleFunc("PATCH /v1/apps/{app}/collaborators/{id}", f.handleCollaboratorUpdate)
+	mux.HandleFunc("DELETE /v1/apps/{app}/collaborators/{id}", f.handleCollaboratorRemove)
+
+	mux.HandleFunc("GET /v1/apps/{app}/notifiers", f.handleNotifiersList)
+	mux.HandleFunc("POST /v1/apps/{app}/notifiers", f.handleNotifierProvision)
+	mux.HandleFunc("GET /v1/apps/{app}/notifiers/{id}", f.handleNotifierShow)
+	mux.HandleFunc("PATCH /v1/apps/{app}/notifiers/{id}", f.handleNotifierUpdate)
+	mux.HandleFunc("DELETE /v1/apps/{app}/notifiers/{id}", f.handleNotifierDestroy)
+
+	mux.HandleFunc("GET /v1/apps/{app}/scm_repo_link", f.handleSCMRepoLinkShow)
+	mux.HandleFunc("POST /v1/apps/{app}/scm_repo_link", f.handleSCMRepoLinkCreate)
+	mux.HandleFunc("PATCH /v1/apps/{app}/scm_repo_link", f.handleSCMRepoLinkUpdate)
+	mux.HandleFunc("DELETE /v1/apps/{app}/scm_repo_link", f.handleSCMRepoLinkDelete)
+	mux.HandleFunc("POST /v1/apps/{app}/scm_repo_link/manual_deploy", f.handleSCMRepoLinkManualDeploy)
+
+	mux.HandleFunc("POST /v1/sources", f.handleSourcesCreate)
+	mux.HandleFunc("PUT /v1/sources/{id}/upload", f.handleSourceUpload)
+
+	mux.HandleFunc("GET /scm/{owner}/{repo}/archive/{archive}", f.handleSCMArchive)
+	mux.HandleFunc("GET /scm/{owner}/{repo}/-/archive/{ref}/{archive}", f.handleSCMArchive)
+
+	mux.HandleFunc("GET /v1/databases", f.handleDatabasesList)
+	mux.HandleFunc("POST /v1/databases", f.handleDatabaseCreate)
+
+	mux.HandleFunc("GET /v1/projects", f.handleProjectsList)
+	mux.HandleFunc("POST /v1/projects", f.handleProjectAdd)
+	mux.HandleFunc("GET /v1/projects/{id}", f.handleProjectGet)
+	mux.HandleFunc("PATCH /v1/projects/{id}", f.handleProjectUpdate)
+	mux.HandleFunc("DELETE /v1/projects/{id}", f.handleProjectDelete)
+
+	mux.HandleFunc("GET /v1/addon_providers", f.handleAddonProvidersList)
+	mux.HandleFunc("GET /v1/addon_providers/{id}/plans", f.handleAddonProviderPlansList)
+	mux.HandleFunc("GET /v1/features/container_sizes", f.handleContainerSizesList)
+	mux.HandleFunc("GET /v1/features/stacks", f.handleStacksList)
+	mux.HandleFunc("GET /v1/notification_platforms", f.handleNotificationPlatformsList)
+	mux.HandleFunc("GET /v1/event_types", f.handleEventTypesList)
+	mux.HandleFunc("GET /v1/events", f.handleUserEventsList)
+	mux.HandleFunc("GET /v1/account/invoices", f.handleInvoicesList)
+}
+
+// createApp registers a new application in the fake state.
+func (f *fakeAPI) createApp(opts scalingo.AppsCreateOpts) *fakeApp {
+	now := time.Now()
+	app := &fakeApp{App: scalingo.App{
+		ID:          f.nextID("app"),
+		Name:        opts.Name,
+		Region:      f.region,
+		Owner:       fakeOwner,
+		GitURL:      "git@ssh." + f.region + ".fake:" + opts.Name + ".git",
+		URL:         "https://" + opts.Name + "." + f.region + ".fake",
+		BaseURL:     "https://" + opts.Name + "." + f.region + ".fake",
+		Status:      scalingo.AppStatusNew,
+		CreatedAt:   &now,
+		UpdatedAt:   &now,
+		StackID:     opts.StackID,
+		HDSResource: opts.HDSResource,
+		Flags:       map[string]bool{},
+		Limits:      map[string]any{},
+	}}
+	if app.StackID == "" {
+		app.StackID = "st-scalingo-22"
+	}
+	app.Links = &scalingo.AppLinks{DeploymentsStream: "ws" + strings.TrimPrefix(f.stream.URL, "http") + "/apps/" + app.ID}
+	if opts.ProjectID != "" {
+		app.Project.ID = opts.ProjectID
+	}
+	app.containers = []scalingo.ContainerType{{AppID: app.ID, Name: "web", Amount: 1, Size: "M"}}
+	app.privateNetworkDomains = []string{
+		"web-1." + opts.Name + ".pn.fake",
+		"web-2." + opts.Name + ".pn.fake",
+	}
+	f.apps = append(f.apps, app)
+	return app
+}
+
+func (f *fakeAPI) handleAppsList(w http.ResponseWriter, _ *http.Request) {
+	apps := []*scalingo.App{}
+	for _, app := range f.apps {
+		a := app.App
+		apps = append(apps, &a)
+	}
+	writeJSON(w, http.StatusOK, map[string][]*scalingo.App{"apps": apps})
+}
+
+func (f *fakeAPI) handleAppsCreate(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		App scalingo.AppsCreateOpts `json:"app"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	if payload.App.Name == "" {
+		writeUnprocessable(w, "name", "can't be blank")
+		return
+	}
+	if f.findApp(payload.App.Name) != nil {
+		writeUnprocessable(w, "name", "has already been taken")
+		return
+	}
+	app := f.createApp(payload.App)
+	writeJSON(w, http.StatusCreated, scalingo.AppResponse{App: &app.App})
+}
+
+func (f *fakeAPI) handleAppsShow(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, scalingo.AppResponse{App: &app.App})
+}
+
+func (f *fakeAPI) handleAppsUpdate(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload struct {
+		ForceHTTPS    *bool   `json:"force_https"`
+		RouterLogs    *bool   `json:"router_logs"`
+		StickySession *bool   `json:"sticky_session"`
+		ProjectID     *string `json:"project_id"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	if payload.ForceHTTPS != nil {
+		app.ForceHTTPS = *payload.ForceHTTPS
+	}
+	if payload.RouterLogs != nil {
+		app.RouterLogs = *payload.RouterLogs
+	}
+	if payload.StickySession != nil {
+		app.StickySession = *payload.StickySession
+	}
+	if payload.ProjectID != nil {
+		app.Project.ID = *payload.ProjectID
+		if app.databaseNG != nil {
+			app.databaseNG.ProjectID = *payload.ProjectID
+		}
+	}
+	writeJSON(w, http.StatusOK, scalingo.AppResponse{App: &app.App})
+}
+
+func (f *fakeAPI) handleAppsPatch(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload struct {
+		App struct {
+			StackID *string `json:"stack_id"`
+			Owner   *string `json:"owner"`
+		} `json:"app"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	if payload.App.StackID != nil {
+		found := false
+		for _, stack := range f.stacks {
+			if stack.ID == *payload.App.StackID {
+				found = true
+			}
+		}
+		if !found {
+			writeUnprocessable(w, "stack_id", "is invalid")
+			return
+		}
+		app.StackID = *payload.App.StackID
+	}
+	if payload.App.Owner != nil {
+		app.Owner = scalingo.Owner{
+			ID:       f.nextID("us"),
+			Username: strings.Split(*payload.App.Owner, "@")[0],
+			Email:    *payload.App.Owner,
+		}
+	}
+	writeJSON(w, http.StatusOK, scalingo.AppResponse{App: &app.App})
+}
+
+func (f *fakeAPI) handleAppsDestroy(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	if r.URL.Query().Get("current_name") != app.Name {
+		writeUnprocessable(w, "current_name", "does not match the application name")
+		return
+	}
+	for i, a := range f.apps {
+		if a == app {
+			f.apps = append(f.apps[:i], f.apps[i+1:]...)
+			break
+		}
+	}
+	for _, addon := range app.addons {
+		delete(f.databases, addon.ID)
+	}
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (f *fakeAPI) handleAppsRename(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload struct {
+		CurrentName string `json:"current_name"`
+		NewName     string `json:"new_name"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	if payload.CurrentName != app.Name {
+		writeUnprocessable(w, "current_name", "does not match the application name")
+		return
+	}
+	if other := f.findApp(payload.NewName); other != nil && other != app {
+		writeUnprocessable(w, "name", "has already been taken")
+		return
+	}
+	app.Name = payload.NewName
+	app.GitURL = "git@ssh." + f.region + ".fake:" + app.Name + ".git"
+	app.URL = "https://" + app.Name + "." + f.region + ".fake"
+	app.BaseURL = app.URL
+	if app.databaseNG != nil {
+		app.databaseNG.Name = app.Name
+	}
+	writeJSON(w, http.StatusOK, scalingo.AppResponse{App: &app.App})
+}
+
+func (f *fakeAPI) handleAppsRestart(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	if app.operationInProgress() {
+		writeUnprocessable(w, "app", "an operation is already in progress on this application")
+		return
+	}
+	var params scalingo.AppsRestartParams
+	// The scope is optional: a null body restarts every container.
+	_ = json.NewDecoder(r.Body).Decode(&params)
+	app.restarts = append(app.restarts, params)
+
+	w.Header().Set("Location", f.newOperation(app, "restart"))
+	writeJSON(w, http.StatusAccepted, nil)
+}
+
+func (f *fakeAPI) handleAppsScale(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	if app.operationInProgress() {
+		writeUnprocessable(w, "app", "an operation is already in progress on this application")
+		return
+	}
+	var params scalingo.AppsScaleParams
+	if !decodeBody(w, r, &params) {
+		return
+	}
+	for _, requested := range params.Containers {
+		found := false
+		for i := range app.containers {
+			if app.containers[i].Name != requested.Name {
+				continue
+			}
+			found = true
+			app.containers[i].Amount = requested.Amount
+			if requested.Size != "" {
+				app.containers[i].Size = requested.Size
+			}
+		}
+		if !found {
+			size := requested.Size
+			if size == "" {
+				size = "M"
+			}
+			app.containers = append(app.containers, scalingo.ContainerType{
+				AppID: app.ID, Name: requested.Name, Amount: requested.Amount, Size: size,
+			})
+		}
+	}
+
+	app.bootingPolls = f.pendingPolls
+	w.Header().Set("Location", f.newOperation(app, scalingo.OperationTypeScale))
+	writeJSON(w, http.StatusAccepted, scalingo.ScaleRes{Containers: app.containers})
+}
+
+// handleAppsContainersPs lists one container per unit of the amount of each
+// container type.
+func (f *fakeAPI) handleAppsContainersPs(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	booting := app.bootingPolls > 0
+	if booting {
+		app.bootingPolls--
+	}
+
+	containers := []scalingo.Container{}
+	for _, ct := range app.containers {
+		state := "running"
+		if booting {
+			state = "booting"
+		} else if app.containerStates[ct.Name] != "" {
+			state = app.containerStates[ct.Name]
+		}
+		for i := 1; i <= ct.Amount; i++ {
+			containers = append(containers, scalingo.Container{
+				ID:            fmt.Sprintf("ct-%v-%v-%d", app.ID, ct.Name, i),
+				AppID:         app.ID,
+				Type:          ct.Name,
+				TypeIndex:     i,
+				Label:         fmt.Sprintf("%v-%d", ct.Name, i),
+				State:         state,
+				ContainerSize: scalingo.ContainerSize{Name: ct.Size},
+			})
+		}
+	}
+	for _, oneOff := range app.oneOffs {
+		switch {
+		case oneOff.pending > 0:
+			oneOff.pending--
+			oneOff.State = "running"
+		case oneOff.crashed && !oneOff.removed:
+			oneOff.State = "crashed"
+			oneOff.removed = true
+		default:
+			continue
+		}
+		containers = append(containers, oneOff.Container)
+	}
+	writeJSON(w, http.StatusOK, scalingo.AppsPsRes{Containers: containers})
+}
+
+// handleAppsStats reports the same usage for every container: 10% of CPU and
+// half of the memory of its size.
+func (f *fakeAPI) handleAppsStats(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	stats := []*scalingo.ContainerStat{}
+	for _, ct := range app.containers {
+		var memory int64
+		for _, size := range f.containerSizes {
+			if size.Name == ct.Size {
+				memory = int64(size.Memory)
+			}
+		}
+		for i := 1; i <= ct.Amount; i++ {
+			stats = append(stats, &scalingo.ContainerStat{
+				ID:                 fmt.Sprintf("%v-%d", ct.Name, i),
+				CPUUsage:           10,
+				MemoryUsage:        memory / 2,
+				MemoryLimit:        memory,
+				HighestMemoryUsage: memory * 3 / 4,
+				SwapLimit:          memory,
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, scalingo.AppStatsRes{Stats: stats})
+}
+
+// handleRun starts a detached one-off container. Its output has more lines
+// than reported in the errors of the provider.
+func (f *fakeAPI) handleRun(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload struct {
+		Command  string            `json:"command"`
+		Env      map[string]string `json:"env"`
+		Size     string            `json:"size"`
+		Detached bool              `json:"detached"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	if !payload.Detached {
+		writeUnprocessable(w, "detached", "the fake API only runs detached one-off containers")
+		return
+	}
+	size := payload.Size
+	if size == "" {
+		size = "M"
+	}
+
+	index := len(app.oneOffs) + 1
+	oneOff := &fakeOneOff{
+		Container: scalingo.Container{
+			ID:            f.nextID("ct"),
+			AppID:         app.ID,
+			Command:       payload.Command,
+			Type:          "one-off",
+			TypeIndex:     index,
+			Label:         fmt.Sprintf("one-off-%d", index),
+			State:         "starting",
+			ContainerSize: scalingo.ContainerSize{Name: size},
+		},
+		env:     payload.Env,
+		pending: f.pendingPolls,
+		crashed: f.oneOffCrash,
+	}
+	oneOff.output = append(oneOff.output, "Running "+payload.Command)
+	for i := 1; i <= 25; i++ {
+		oneOff.output = append(oneOff.output, fmt.Sprintf("output line %d", i))
+	}
+	app.oneOffs = append(app.oneOffs, oneOff)
+
+	location := f.newOperation(app, scalingo.OperationTypeStartOneOff)
+	op := app.operations[len(app.operations)-1]
+	op.StartOneOffData = scalingo.OperationStartOneOffData{ContainerID: oneOff.ID}
+	writeJSON(w, http.StatusOK, scalingo.RunRes{Container: &oneOff.Container, OperationURL: location})
+}
+
+func (f *fakeAPI) handleCronTasksGet(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	if app.cronTasks == nil {
+		writeNotFound(w, "cron_tasks")
+		return
+	}
+	writeJSON(w, http.StatusOK, app.cronTasks)
+}
+
+// addEvent records an event of the application with the given type data.
+func (f *fakeAPI) addEvent(app *fakeApp, eventType scalingo.EventTypeName, createdAt time.Time, typeData any) *scalingo.Event {
+	rawTypeData, err := json.Marshal(typeData)
+	if err != nil {
+		f.t.Fatalf("marshal event type data: %v", err)
+	}
+	event := &scalingo.Event{
+		ID:          f.nextID("event"),
+		AppID:       app.ID,
+		AppName:     app.Name,
+		CreatedAt:   createdAt,
+		User:        scalingo.EventUser{ID: fakeOwner.ID, Username: fakeOwner.Username, Email: fakeOwner.Email},
+		Type:        eventType,
+		RawTypeData: rawTypeData,
+	}
+	app.events = append(app.events, event)
+	slices.SortStableFunc(app.events, func(a, b *scalingo.Event) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return event
+}
+
+func (f *fakeAPI) handleEventsList(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	writeEvents(w, r, app.events)
+}
+
+// handleUserEventsList answers the events of all the applications.
+func (f *fakeAPI) handleUserEventsList(w http.ResponseWriter, r *http.Request) {
+	events := []*scalingo.Event{}
+	for _, app := range f.apps {
+		events = append(events, app.events...)
+	}
+	slices.SortStableFunc(events, func(a, b *scalingo.Event) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	writeEvents(w, r, events)
+}
+
+func writeEvents(w http.ResponseWriter, r *http.Request, events []*scalingo.Event) {
+	page, perPage := paginationParams(r.URL.Query())
+	data, meta := paginate(events, page, perPage)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"events": data,
+		"meta":   map[string]any{"pagination": meta},
+	})
+}
+
+func (f *fakeAPI) handleLogsURL(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, scalingo.LogsURLRes{LogsURL: f.api.URL + "/logs/" + app.ID + "?token=logs-token"})
+}
+
+// handleLogs returns the last n lines of the output of the one-off
+// containers matching the filter.
+func (f *fakeAPI) handleLogs(w http.ResponseWriter, r *http.Request) {
+	app := f.findApp(r.PathValue("app"))
+	if app == nil || r.URL.Query().Get("token") != "logs-token" {
+		writeNotFound(w, "app")
+		return
+	}
+	var lines []string
+	for _, oneOff := range app.oneOffs {
+		if filter := r.URL.Query().Get("filter"); filter == "" || filter == oneOff.Label {
+			lines = append(lines, oneOff.output...)
+		}
+	}
+	if n, err := strconv.Atoi(r.URL.Query().Get("n")); err == nil && n < len(lines) {
+		lines = lines[len(lines)-n:]
+	}
+	w.WriteHeader(http.StatusOK)
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+}
+
+func (f *fakeAPI) handleAppsContainerTypes(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, scalingo.AppsContainerTypesRes{Containers: app.containers})
+}
+
+// operationInProgress returns true if an operation of the application hasn't
+// been polled until its end: like the real API, the fake rejects concurrent
+// operations on an application.
+func (app *fakeApp) operationInProgress() bool {
+	for _, op := range app.operations {
+		if op.Status != op.finalStatus {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeAPI) handleOperationsShow(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	for _, op := range app.operations {
+		if op.ID != r.PathValue("id") {
+			continue
+		}
+		if op.pending > 0 {
+			op.pending--
+			op.Status = scalingo.OperationStatusRunning
+		} else {
+			op.Status = op.finalStatus
+			op.Error = op.finalError
+			op.FinishedAt = time.Now()
+		}
+		writeJSON(w, http.StatusOK, scalingo.OperationResponse{Op: op.Operation})
+		return
+	}
+	writeNotFound(w, "operation")
+}
+
+// createDeployment registers a new deployment of the application. Its build
+// output has more lines than reported in the errors of the provider.
+func (f *fakeAPI) createDeployment(app *fakeApp, params scalingo.DeploymentsCreateParams) *fakeDeployment {
+	now := time.Now()
+	deployment := &fakeDeployment{
+		Deployment: scalingo.Deployment{
+			AppID:     app.ID,
+			CreatedAt: &now,
+			Status:    scalingo.StatusQueued,
+		},
+		pending:     f.pendingPolls,
+		finalStatus: scalingo.StatusSuccess,
+		output:      []string{"-----> Fetching source code from " + params.SourceURL},
+	}
+	deployment.ID = f.nextID("deployment")
+	deployment.Links = &scalingo.DeploymentLinks{
+		Output: f.api.URL + "/v1/apps/" + app.ID + "/deployments/" + deployment.ID + "/output",
+	}
+	if params.GitRef != nil {
+		deployment.GitRef = *params.GitRef
+	}
+	for _, stack := range f.stacks {
+		if stack.ID == app.StackID {
+			deployment.StackBaseImage = stack.BaseImage
+		}
+	}
+	if f.deploymentStatus != "" {
+		deployment.finalStatus = f.deploymentStatus
+	}
+	for i := 1; i <= 25; i++ {
+		deployment.output = append(deployment.output, fmt.Sprintf("       Installing dependency %d", i))
+	}
+	if deployment.finalStatus == scalingo.StatusSuccess {
+		deployment.output = append(deployment.output, "-----> Build complete")
+	} else {
+		deployment.output = append(deployment.output, " !     Build failed: "+string(deployment.finalStatus))
+	}
+	app.deployments = append(app.deployments, deployment)
+	return deployment
+}
+
+func (app *fakeApp) findDeployment(id string) *fakeDeployment {
+	for _, deployment := range app.deployments {
+		if deployment.ID == id {
+			return deployment
+		}
+	}
+	return nil
+}
+
+// handleDeploymentsList lists the deployments from the most recent one, as
+// the API does.
+func (f *fakeAPI) handleDeploymentsList(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	deployments := []*scalingo.Deployment{}
+	for i := len(app.deployments) - 1; i >= 0; i-- {
+		deployments = append(deployments, &app.deployments[i].Deployment)
+	}
+	writeJSON(w, http.StatusOK, scalingo.DeploymentList{Deployments: deployments})
+}
+
+func (f *fakeAPI) handleDeploymentsCreate(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload struct {
+		Deployment scalingo.DeploymentsCreateParams `json:"deployment"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	if payload.Deployment.SourceURL == "" {
+		writeUnprocessable(w, "source_url", "can't be blank")
+		return
+	}
+	if _, uploaded := f.sources[payload.Deployment.SourceURL]; strings.HasPrefix(payload.Deployment.SourceURL, f.api.URL+"/v1/sources/") && !uploaded {
+		writeUnprocessable(w, "source_url", "has not been uploaded")
+		return
+	}
+	deployment := f.createDeployment(app, payload.Deployment)
+	writeJSON(w, http.StatusCreated, scalingo.DeploymentsCreateRes{Deployment: &deployment.Deployment})
+}
+
+func (f *fakeAPI) handleDeploymentShow(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	deployment := app.findDeployment(r.PathValue("id"))
+	if deployment == nil {
+		writeNotFound(w, "deployment")
+		return
+	}
+	if deployment.pending > 0 {
+		deployment.pending--
+		deployment.Status = scalingo.StatusBuilding
+	} else if !deployment.IsFinished() {
+		deployment.Status = deployment.finalStatus
+		deployment.Duration = 42
+		if deployment.Status == scalingo.StatusSuccess {
+			deployment.ImageSize = 123456789
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]*scalingo.Deployment{"deployment": &deployment.Deployment})
+}
+
+func (f *fakeAPI) handleDeploymentOutput(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	deployment := app.findDeployment(r.PathValue("id"))
+	if deployment == nil {
+		writeNotFound(w, "deployment")
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	_, _ = w.Write([]byte(strings.Join(deployment.output, "\n") + "\n"))
+}
+
+func (f *fakeAPI) handleSourcesCreate(w http.ResponseWriter, _ *http.Request) {
+	url := f.api.URL + "/v1/sources/" + f.nextID("source")
+	writeJSON(w, http.StatusCreated, scalingo.SourcesCreateResponse{Source: &scalingo.Source{
+		DownloadURL: url,
+		UploadURL:   url + "/upload",
+	}})
+}
+
+func (f *fakeAPI) handleSourceUpload(w http.ResponseWriter, r *http.Request) {
+	archive, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	f.sources[f.api.URL+"/v1/sources/"+r.PathValue("id")] = archive
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleSCMArchive serves the archives of the SCM repositories linked with an
+// URL under /scm, in the formats of GitHub and GitLab.
+func (f *fakeAPI) handleSCMArchive(w http.ResponseWriter, r *http.Request) {
+	if f.privateRepos[r.PathValue("owner")+"/"+r.PathValue("repo")] {
+		writeNotFound(w, "repository")
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-gzip")
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleDeploymentStream sends the build output of the deployments of the
+// application on a websocket, then keeps it open until the client leaves.
+func (f *fakeAPI) handleDeploymentStream(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var auth scalingo.AuthStruct
+	if conn.ReadJSON(&auth) != nil || auth.Type != "auth" || auth.Data.Token == "" {
+		return
+	}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, "STREAM "+r.URL.Path)
+	events := []scalingo.DeploymentEvent{}
+	if app := f.findApp(strings.TrimPrefix(r.URL.Path, "/apps/")); app != nil {
+		for _, deployment := range app.deployments {
+			for _, line := range deployment.output {
+				data, _ := json.Marshal(scalingo.DeploymentEventDataLog{Content: line + "\n"})
+				events = append(events, scalingo.DeploymentEvent{ID: deployment.ID, Type: scalingo.DeploymentEventTypeLog, Data: data})
+			}
+		}
+	}
+	f.mu.Unlock()
+
+	for _, event := range events {
+		if conn.WriteJSON(event) != nil {
+			return
+		}
+	}
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeAPI) handlePrivateNetworkDomainsList(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	page, perPage := paginationParams(r.URL.Query())
+	data, meta := paginate(app.privateNetworkDomains, page, perPage)
+	writeJSON(w, http.StatusOK, map[string]any{"domain_names": map[string]any{"data": data, "meta": meta}})
+}
+
+func paginationParams(query url.Values) (int, int) {
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(query.Get("per_page"))
+	if perPage < 1 {
+		perPage = 20
+	}
+	return page, perPage
+}
+
+func paginate[T any](items []T, page, perPage int) ([]T, map[string]int) {
+	totalPages := (len(items) + perPage - 1) / perPage
+	meta := map[string]int{
+		"current_page": page,
+		"per_page":     perPage,
+		"total_pages":  totalPages,
+		"total_count":  len(items),
+	}
+	if page < totalPages {
+		meta["next_page"] = page + 1
+	}
+	if page > 1 {
+		meta["prev_page"] = page - 1
+	}
+
+	start := (page - 1) * perPage
+	if start >= len(items) {
+		return []T{}, meta
+	}
+	end := min(start+perPage, len(items))
+	return items[start:end], meta
+}
+
+func (f *fakeAPI) handleVariablesList(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	variables := scalingo.Variables{}
+	variables = append(variables, app.variables...)
+	writeJSON(w, http.StatusOK, map[string]scalingo.Variables{"variables": variables})
+}
+
+func (app *fakeApp) setVariable(f *fakeAPI, name, value string) *scalingo.Variable {
+	if variable, ok := app.variables.Contains(name); ok {
+		variable.Value = value
+		return variable
+	}
+	variable := &scalingo.Variable{ID: f.nextID("var"), Name: name, Value: value}
+	app.variables = append(app.variables, variable)
+	return variable
+}
+
+func (f *fakeAPI) handleVariablesMultipleSet(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload struct {
+		Variables scalingo.Variables `json:"variables"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	variables := scalingo.Variables{}
+	for _, v := range payload.Variables {
+		variables = append(variables, app.setVariable(f, v.Name, v.Value))
+	}
+	writeJSON(w, http.StatusOK, map[string]scalingo.Variables{"variables": variables})
+}
+
+func (f *fakeAPI) handleVariableSet(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload struct {
+		Variable scalingo.Variable `json:"variable"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	status := http.StatusOK
+	if _, ok := app.variables.Contains(payload.Variable.Name); !ok {
+		status = http.StatusCreated
+	}
+	variable := app.setVariable(f, payload.Variable.Name, payload.Variable.Value)
+	writeJSON(w, status, map[string]*scalingo.Variable{"variable": variable})
+}
+
+func (f *fakeAPI) handleVariableUnset(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	for i, variable := range app.variables {
+		if variable.ID == r.PathValue("id") {
+			app.variables = append(app.variables[:i], app.variables[i+1:]...)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "variable")
+}
+
+func (f *fakeAPI) handleAddonsList(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	addons := []*scalingo.Addon{}
+	for _, addon := range app.addons {
+		a := addon.Addon
+		addons = append(addons, &a)
+	}
+	writeJSON(w, http.StatusOK, scalingo.AddonsRes{Addons: addons})
+}
+
+// provisionAddon registers a new addon on the application. Database addons
+// are also registered on the Database API.
+func (f *fakeAPI) provisionAddon(app *fakeApp, provider *scalingo.AddonProvider, plan *scalingo.Plan) *fakeAddon {
+	p := *plan
+	ap := *provider
+	addon := &fakeAddon{
+		Addon: scalingo.Addon{
+			ID:            f.nextID("ad"),
+			AppID:         app.ID,
+			ResourceID:    f.nextID(provider.ID),
+			Status:        scalingo.AddonStatusRunning,
+			Plan:          &p,
+			AddonProvider: &ap,
+			ProvisionedAt: time.Now(),
+		},
+		pending: f.pendingPolls,
+	}
+	app.addons = append(app.addons, addon)
+
+	if strings.HasPrefix(strings.ToLower(provider.Category.Name), "database") {
+		f.databases[addon.ID] = &fakeDatabase{
+			Database: scalingo.Database{
+				ID:         addon.ID,
+				CreatedAt:  time.Now(),
+				ResourceID: addon.ResourceID,
+				AppName:    app.Name,
+				Plan:       plan.Name,
+				Status:     scalingo.DatabaseStatusRunning,
+				TypeName:   provider.ID,
+				Features:   []scalingo.DatabaseFeature{},
+			},
+			pending:       f.pendingPolls,
+			pendingStatus: scalingo.DatabaseStatusCreating,
+		}
+	}
+	return addon
+}
+
+func (f *fakeAPI) handleAddonProvision(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload scalingo.AddonProvisionParamsWrapper
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	provider := f.findAddonProvider(payload.Addon.AddonProviderID)
+	if provider == nil {
+		writeNotFound(w, "addon_provider")
+		return
+	}
+	plan := findPlan(provider, payload.Addon.PlanID)
+	if plan == nil {
+		writeNotFound(w, "plan")
+		return
+	}
+	addon := f.provisionAddon(app, provider, plan)
+	res := addon.Addon
+	res.Status = scalingo.AddonStatusProvisioning
+	writeJSON(w, http.StatusCreated, scalingo.AddonRes{Addon: res, Message: "addon is being provisioned"})
+}
+
+func (f *fakeAPI) handleAddonShow(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	addon := app.findAddon(r.PathValue("id"))
+	if addon == nil {
+		writeNotFound(w, "addon")
+		return
+	}
+	res := addon.Addon
+	if addon.pending > 0 {
+		addon.pending--
+		res.Status = scalingo.AddonStatusProvisioning
+	}
+	writeJSON(w, http.StatusOK, scalingo.AddonRes{Addon: res})
+}
+
+func (f *fakeAPI) handleAddonUpgrade(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	addon := app.findAddon(r.PathValue("id"))
+	if addon == nil {
+		writeNotFound(w, "addon")
+		return
+	}
+	var payload scalingo.AddonUpgradeParamsWrapper
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	plan := findPlan(f.findAddonProvider(addon.AddonProvider.ID), payload.Addon.PlanID)
+	if plan == nil {
+		writeNotFound(w, "plan")
+		return
+	}
+	p := *plan
+	addon.Plan = &p
+	addon.pending = f.pendingPolls
+	if db, ok := f.databases[addon.ID]; ok {
+		db.Plan = plan.Name
+		db.pending = f.pendingPolls
+		db.pendingStatus = scalingo.DatabaseStatusUpdating
+	}
+	if app.databaseNG != nil {
+		app.databaseNG.Plan = plan.Name
+	}
+	res := addon.Addon
+	res.Status = scalingo.AddonStatusProvisioning
+	writeJSON(w, http.StatusOK, scalingo.AddonRes{Addon: res, Message: "addon is being upgraded"})
+}
+
+func (f *fakeAPI) handleAddonDestroy(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	for i, addon := range app.addons {
+		if addon.ID == r.PathValue("id") {
+			app.addons = append(app.addons[:i], app.addons[i+1:]...)
+			delete(f.databases, addon.ID)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "addon")
+}
+
+func (f *fakeAPI) handleAddonToken(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	if app.findAddon(r.PathValue("id")) == nil {
+		writeNotFound(w, "addon")
+		return
+	}
+	writeJSON(w, http.StatusOK, scalingo.AddonTokenRes{Addon: scalingo.AddonToken{Token: "addon-token-" + r.PathValue("id")}})
+}
+
+// logDrains returns the log drains targeted by the request: the ones of the
+// addon when an {id} path value is present, the ones of the application
+// otherwise.
+func (f *fakeAPI) logDrains(w http.ResponseWriter, r *http.Request) (*fakeApp, *[]scalingo.LogDrain) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return nil, nil
+	}
+	addonID := r.PathValue("id")
+	if addonID == "" {
+		return app, &app.logDrains
+	}
+	addon := app.findAddon(addonID)
+	if addon == nil {
+		writeNotFound(w, "addon")
+		return nil, nil
+	}
+	return app, &addon.logDrains
+}
+
+func (f *fakeAPI) handleLogDrainsList(w http.ResponseWriter, r *http.Request) {
+	_, drains := f.logDrains(w, r)
+	if drains == nil {
+		return
+	}
+	res := []scalingo.LogDrain{}
+	res = append(res, *drains...)
+	writeJSON(w, http.StatusOK, scalingo.LogDrainsRes{Drains: res})
+}
+
+func (f *fakeAPI) handleLogDrainAdd(w http.ResponseWriter, r *http.Request) {
+	app, drains := f.logDrains(w, r)
+	if drains == nil {
+		return
+	}
+	var payload scalingo.LogDrainAddPayload
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	params := payload.Drain
+	drainURL := params.URL
+	if drainURL == "" {
+		drainURL = fmt.Sprintf("%s://%s:%s", params.Type, params.Host, params.Port)
+		if params.Token != "" {
+			drainURL += "?token=" + params.Token
+		}
+	}
+	drain := scalingo.LogDrain{AppID: app.ID, URL: drainURL}
+	*drains = append(*drains, drain)
+	writeJSON(w, http.StatusCreated, scalingo.LogDrainRes{Drain: drain})
+}
+
+func (f *fakeAPI) handleLogDrainRemove(w http.ResponseWriter, r *http.Request) {
+	_, drains := f.logDrains(w, r)
+	if drains == nil {
+		return
+	}
+	for i, drain := range *drains {
+		if drain.URL == r.URL.Query().Get("url") {
+			*drains = append((*drains)[:i], (*drains)[i+1:]...)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "log_drain")
+}
+
+func (app *fakeApp) findDomain(id string) *scalingo.Domain {
+	for _, domain := range app.domains {
+		if domain.ID == id {
+			return domain
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPI) handleDomainsList(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	domains := []scalingo.Domain{}
+	for _, domain := range app.domains {
+		domains = append(domains, *domain)
+	}
+	writeJSON(w, http.StatusOK, scalingo.DomainsRes{Domains: domains})
+}
+
+func (app *fakeApp) setCanonicalDomain(domain *scalingo.Domain, canonical bool) {
+	if canonical {
+		for _, other := range app.domains {
+			other.Canonical = false
+		}
+	}
+	domain.Canonical = canonical
+}
+
+func (f *fakeAPI) handleDomainsAdd(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload struct {
+		Domain scalingo.DomainsAddParams `json:"domain"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	for _, domain := range app.domains {
+		if domain.Name == payload.Domain.Name {
+			writeUnprocessable(w, "name", "has already been taken")
+			return
+		}
+	}
+	domain := &scalingo.Domain{
+		ID:                 f.nextID("dom"),
+		AppID:              app.ID,
+		Name:               payload.Domain.Name,
+		LetsEncryptEnabled: true,
+	}
+	if payload.Domain.LetsEncryptEnabled != nil {
+		domain.LetsEncryptEnabled = *payload.Domain.LetsEncryptEnabled
+	}
+	app.domains = append(app.domains, domain)
+	if payload.Domain.Canonical != nil {
+		app.setCanonicalDomain(domain, *payload.Domain.Canonical)
+	}
+	writeJSON(w, http.StatusCreated, map[string]scalingo.Domain{"domain": *domain})
+}
+
+func (f *fakeAPI) handleDomainsShow(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	domain := app.findDomain(r.PathValue("id"))
+	if domain == nil {
+		writeNotFound(w, "domain")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]scalingo.Domain{"domain": *domain})
+}
+
+func (f *fakeAPI) handleDomainsUpdate(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	domain := app.findDomain(r.PathValue("id"))
+	if domain == nil {
+		writeNotFound(w, "domain")
+		return
+	}
+	var payload struct {
+		Domain scalingo.DomainsUpdateParams `json:"domain"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	if payload.Domain.Canonical != nil {
+		app.setCanonicalDomain(domain, *payload.Domain.Canonical)
+	}
+	if payload.Domain.LetsEncryptEnabled != nil {
+		domain.LetsEncryptEnabled = *payload.Domain.LetsEncryptEnabled
+	}
+	writeJSON(w, http.StatusOK, map[string]scalingo.Domain{"domain": *domain})
+}
+
+func (f *fakeAPI) handleDomainsRemove(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	for i, domain := range app.domains {
+		if domain.ID == r.PathValue("id") {
+			app.domains = append(app.domains[:i], app.domains[i+1:]...)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "domain")
+}
+
+func (app *fakeApp) findAlert(id string) *scalingo.Alert {
+	for _, alert := range app.alerts {
+		if alert.ID == id {
+			return alert
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPI) handleAlertsList(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	alerts := []*scalingo.Alert{}
+	alerts = append(alerts, app.alerts...)
+	writeJSON(w, http.StatusOK, scalingo.AlertsRes{Alerts: alerts})
+}
+
+func (f *fakeAPI) handleAlertAdd(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload scalingo.AlertRes
+	if !decodeBody(w, r, &payload) || payload.Alert == nil {
+		return
+	}
+	alert := payload.Alert
+	alert.ID = f.nextID("alert")
+	alert.AppID = app.ID
+	alert.CreatedAt = time.Now()
+	alert.UpdatedAt = alert.CreatedAt
+	if alert.Notifiers == nil {
+		alert.Notifiers = []string{}
+	}
+	app.alerts = append(app.alerts, alert)
+	writeJSON(w, http.StatusCreated, scalingo.AlertRes{Alert: alert})
+}
+
+func (f *fakeAPI) handleAlertShow(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	alert := app.findAlert(r.PathValue("id"))
+	if alert == nil {
+		writeNotFound(w, "alert")
+		return
+	}
+	writeJSON(w, http.StatusOK, scalingo.AlertRes{Alert: alert})
+}
+
+func (f *fakeAPI) handleAlertUpdate(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	alert := app.findAlert(r.PathValue("id"))
+	if alert == nil {
+		writeNotFound(w, "alert")
+		return
+	}
+	var params scalingo.AlertUpdateParams
+	if !decodeBody(w, r, &params) {
+		return
+	}
+	if params.ContainerType != nil {
+		alert.ContainerType = *params.ContainerType
+	}
+	if params.Metric != nil {
+		alert.Metric = *params.Metric
+	}
+	if params.Limit != nil {
+		alert.Limit = *params.Limit
+	}
+	if params.Disabled != nil {
+		alert.Disabled = *params.Disabled
+	}
+	if params.DurationBeforeTrigger != nil {
+		alert.DurationBeforeTrigger = *params.DurationBeforeTrigger
+	}
+	if params.RemindEvery != nil {
+		alert.RemindEvery = params.RemindEvery.String()
+	}
+	if params.SendWhenBelow != nil {
+		alert.SendWhenBelow = *params.SendWhenBelow
+	}
+	if params.Notifiers != nil {
+		alert.Notifiers = *params.Notifiers
+	}
+	alert.UpdatedAt = time.Now()
+	writeJSON(w, http.StatusOK, scalingo.AlertRes{Alert: alert})
+}
+
+func (f *fakeAPI) handleAlertRemove(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	for i, alert := range app.alerts {
+		if alert.ID == r.PathValue("id") {
+			app.alerts = append(app.alerts[:i], app.alerts[i+1:]...)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "alert")
+}
+
+func (app *fakeApp) findAutoscaler(id string) *scalingo.Autoscaler {
+	for _, autoscaler := range app.autoscalers {
+		if autoscaler.ID == id {
+			return autoscaler
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPI) handleAutoscalersList(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	autoscalers := []scalingo.Autoscaler{}
+	for _, autoscaler := range app.autoscalers {
+		autoscalers = append(autoscalers, *autoscaler)
+	}
+	writeJSON(w, http.StatusOK, scalingo.AutoscalersRes{Autoscalers: autoscalers})
+}
+
+func (f *fakeAPI) handleAutoscalerAdd(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload scalingo.AutoscalerRes
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	autoscaler := payload.Autoscaler
+	if autoscaler.MinContainers > autoscaler.MaxContainers {
+		writeUnprocessable(w, "min_containers", "must be lower than max_containers")
+		return
+	}
+	autoscaler.ID = f.nextID("au")
+	autoscaler.AppID = app.ID
+	app.autoscalers = append(app.autoscalers, &autoscaler)
+	writeJSON(w, http.StatusCreated, scalingo.AutoscalerRes{Autoscaler: autoscaler})
+}
+
+func (f *fakeAPI) handleAutoscalerShow(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	autoscaler := app.findAutoscaler(r.PathValue("id"))
+	if autoscaler == nil {
+		writeNotFound(w, "autoscaler")
+		return
+	}
+	writeJSON(w, http.StatusOK, scalingo.AutoscalerRes{Autoscaler: *autoscaler})
+}
+
+func (f *fakeAPI) handleAutoscalerUpdate(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	autoscaler := app.findAutoscaler(r.PathValue("id"))
+	if autoscaler == nil {
+		writeNotFound(w, "autoscaler")
+		return
+	}
+	var params scalingo.AutoscalerUpdateParams
+	if !decodeBody(w, r, &params) {
+		return
+	}
+	if params.Metric != nil {
+		autoscaler.Metric = *params.Metric
+	}
+	if params.Target != nil {
+		autoscaler.Target = *params.Target
+	}
+	if params.MinContainers != nil {
+		autoscaler.MinContainers = *params.MinContainers
+	}
+	if params.MaxContainers != nil {
+		autoscaler.MaxContainers = *params.MaxContainers
+	}
+	if params.Disabled != nil {
+		autoscaler.Disabled = *params.Disabled
+	}
+	writeJSON(w, http.StatusOK, scalingo.AutoscalerRes{Autoscaler: *autoscaler})
+}
+
+func (f *fakeAPI) handleAutoscalerRemove(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	for i, autoscaler := range app.autoscalers {
+		if autoscaler.ID == r.PathValue("id") {
+			app.autoscalers = append(app.autoscalers[:i], app.autoscalers[i+1:]...)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "autoscaler")
+}
+
+func (f *fakeAPI) handleCollaboratorsList(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	collaborators := []scalingo.Collaborator{}
+	for _, collaborator := range app.collaborators {
+		collaborators = append(collaborators, *collaborator)
+	}
+	writeJSON(w, http.StatusOK, scalingo.CollaboratorsRes{Collaborators: collaborators})
+}
+
+func (f *fakeAPI) handleCollaboratorAdd(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload scalingo.CollaboratorAddParamsPayload
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	collaborator := &scalingo.Collaborator{
+		ID:        f.nextID("collab"),
+		AppID:     app.ID,
+		Username:  "n/a",
+		Email:     payload.Collaborator.Email,
+		Status:    scalingo.CollaboratorStatusPending,
+		IsLimited: payload.Collaborator.IsLimited,
+	}
+	app.collaborators = append(app.collaborators, collaborator)
+	writeJSON(w, http.StatusCreated, scalingo.CollaboratorRes{Collaborator: *collaborator})
+}
+
+func (f *fakeAPI) handleCollaboratorUpdate(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload scalingo.CollaboratorUpdateParamsPayload
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	for _, collaborator := range app.collaborators {
+		if collaborator.ID == r.PathValue("id") {
+			collaborator.IsLimited = payload.Collaborator.IsLimited
+			writeJSON(w, http.StatusOK, scalingo.CollaboratorRes{Collaborator: *collaborator})
+			return
+		}
+	}
+	writeNotFound(w, "collaborator")
+}
+
+func (f *fakeAPI) handleCollaboratorRemove(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	for i, collaborator := range app.collaborators {
+		if collaborator.ID == r.PathValue("id") {
+			app.collaborators = append(app.collaborators[:i], app.collaborators[i+1:]...)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "collaborator")
+}
+
+func (app *fakeApp) findNotifier(id string) *scalingo.Notifier {
+	for _, notifier := range app.notifiers {
+		if notifier.ID == id {
+			return notifier
+		}
+	}
+	return nil
+}
+
+// applyNotifierParams copies the attributes sent by go-scalingo, see
+// scalingo.NotifierOutput, on the stored notifier.
+func (f *fakeAPI) applyNotifierParams(notifier *scalingo.Notifier, params scalingo.Notifier) bool {
+	for _, platform := range f.notificationPlatforms {
+		if platform.ID == params.PlatformID {
+			notifier.Type = scalingo.NotifierType(platform.Name)
+		}
+	}
+	if notifier.Type == "" {
+		return false
+	}
+	notifier.PlatformID = params.PlatformID
+	notifier.Name = params.Name
+	notifier.Active = params.Active
+	notifier.SendAllEvents = params.SendAllEvents
+	notifier.SendAllAlerts = params.SendAllAlerts
+	notifier.SelectedEventIDs = params.SelectedEventIDs
+	notifier.RawTypeData = params.RawTypeData
+	notifier.UpdatedAt = time.Now()
+	return true
+}
+
+func (f *fakeAPI) handleNotifiersList(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	notifiers := []*scalingo.Notifier{}
+	notifiers = append(notifiers, app.notifiers...)
+	writeJSON(w, http.StatusOK, map[string][]*scalingo.Notifier{"notifiers": notifiers})
+}
+
+func (f *fakeAPI) handleNotifierProvision(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload struct {
+		Notifier scalingo.Notifier `json:"notifier"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	notifier := &scalingo.Notifier{ID: f.nextID("no"), AppID: app.ID, CreatedAt: time.Now()}
+	if !f.applyNotifierParams(notifier, payload.Notifier) {
+		writeNotFound(w, "notification_platform")
+		return
+	}
+	app.notifiers = append(app.notifiers, notifier)
+	writeJSON(w, http.StatusCreated, map[string]*scalingo.Notifier{"notifier": notifier})
+}
+
+func (f *fakeAPI) handleNotifierShow(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	notifier := app.findNotifier(r.PathValue("id"))
+	if notifier == nil {
+		writeNotFound(w, "notifier")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]*scalingo.Notifier{"notifier": notifier})
+}
+
+func (f *fakeAPI) handleNotifierUpdate(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	notifier := app.findNotifier(r.PathValue("id"))
+	if notifier == nil {
+		writeNotFound(w, "notifier")
+		return
+	}
+	var payload struct {
+		Notifier scalingo.Notifier `json:"notifier"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	if !f.applyNotifierParams(notifier, payload.Notifier) {
+		writeNotFound(w, "notification_platform")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]*scalingo.Notifier{"notifier": notifier})
+}
+
+func (f *fakeAPI) handleNotifierDestroy(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	for i, notifier := range app.notifiers {
+		if notifier.ID == r.PathValue("id") {
+			app.notifiers = append(app.notifiers[:i], app.notifiers[i+1:]...)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "notifier")
+}
+
+func (f *fakeAPI) handleSCMRepoLinkShow(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	if app.scmRepoLink == nil {
+		writeNotFound(w, "scm_repo_link")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]*scalingo.SCMRepoLink{"scm_repo_link": app.scmRepoLink})
+}
+
+func (f *fakeAPI) handleSCMRepoLinkManualDeploy(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	if app.scmRepoLink == nil {
+		writeNotFound(w, "scm_repo_link")
+		return
+	}
+	var payload struct {
+		Branch string `json:"branch"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	deployment := f.createDeployment(app, scalingo.DeploymentsCreateParams{
+		GitRef:    &payload.Branch,
+		SourceURL: "https://github.com/" + app.scmRepoLink.Owner + "/" + app.scmRepoLink.Repo + "/archive/" + payload.Branch + ".tar.gz",
+	})
+	writeJSON(w, http.StatusOK, scalingo.SCMRepoLinkManualDeployResponse{Deployment: &deployment.Deployment})
+}
+
+func (f *fakeAPI) handleSCMRepoLinkCreate(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	var payload struct {
+		Link scalingo.SCMRepoLinkCreateParams `json:"scm_repo_link"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	if app.scmRepoLink != nil {
+		writeUnprocessable(w, "app", "is already linked")
+		return
+	}
+	params := payload.Link
+	if params.Source == nil {
+		writeUnprocessable(w, "source", "can't be blank")
+		return
+	}
+	source, err := url.Parse(*params.Source)
+	if err != nil {
+		writeUnprocessable(w, "source", "is invalid")
+		return
+	}
+	parts := strings.Split(strings.Trim(source.Path, "/"), "/")
+	if len(parts) != 2 {
+		writeUnprocessable(w, "source", "is invalid")
+		return
+	}
+
+	link := &scalingo.SCMRepoLink{
+		ID:        f.nextID("link"),
+		AppID:     app.ID,
+		URL:       source.Scheme + "://" + source.Host,
+		Owner:     parts[0],
+		Repo:      parts[1],
+		SCMType:   scalingo.SCMGithubType,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if params.Branch != nil {
+		link.Branch = *params.Branch
+	}
+	if params.AuthIntegrationUUID != nil {
+		link.AuthIntegrationUUID = *params.AuthIntegrationUUID
+	}
+	if params.AutoDeployEnabled != nil {
+		link.AutoDeployEnabled = *params.AutoDeployEnabled
+	}
+	if params.DeployReviewAppsEnabled != nil {
+		link.DeployReviewAppsEnabled = *params.DeployReviewAppsEnabled
+	}
+	if params.DestroyOnCloseEnabled != nil {
+		link.DeleteOnCloseEnabled = *params.DestroyOnCloseEnabled
+	}
+	if params.HoursBeforeDeleteOnClose != nil {
+		link.HoursBeforeDeleteOnClose = *params.HoursBeforeDeleteOnClose
+	}
+	if params.DestroyStaleEnabled != nil {
+		link.DeleteStaleEnabled = *params.DestroyStaleEnabled
+	}
+	if params.HoursBeforeDeleteStale != nil {
+		link.HoursBeforeDeleteStale = *params.HoursBeforeDeleteStale
+	}
+	if params.AutomaticCreationFromForksAllowed != nil {
+		link.AutomaticCreationFromForksAllowed = *params.AutomaticCreationFromForksAllowed
+	}
+	app.scmRepoLink = link
+	writeJSON(w, http.StatusCreated, map[string]*scalingo.SCMRepoLink{"scm_repo_link": link})
+}
+
+func (f *fakeAPI) handleSCMRepoLinkUpdate(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	if app.scmRepoLink == nil {
+		writeNotFound(w, "scm_repo_link")
+		return
+	}
+	var payload struct {
+		Link scalingo.SCMRepoLinkUpdateParams `json:"scm_repo_link"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	link, params := app.scmRepoLink, payload.Link
+	if params.Branch != nil {
+		link.Branch = *params.Branch
+	}
+	if params.AutoDeployEnabled != nil {
+		link.AutoDeployEnabled = *params.AutoDeployEnabled
+	}
+	if params.DeployReviewAppsEnabled != nil {
+		link.DeployReviewAppsEnabled = *params.DeployReviewAppsEnabled
+	}
+	if params.DestroyOnCloseEnabled != nil {
+		link.DeleteOnCloseEnabled = *params.DestroyOnCloseEnabled
+	}
+	if params.HoursBeforeDeleteOnClose != nil {
+		link.HoursBeforeDeleteOnClose = *params.HoursBeforeDeleteOnClose
+	}
+	if params.DestroyStaleEnabled != nil {
+		link.DeleteStaleEnabled = *params.DestroyStaleEnabled
+	}
+	if params.HoursBeforeDeleteStale != nil {
+		link.HoursBeforeDeleteStale = *params.HoursBeforeDeleteStale
+	}
+	if params.AutomaticCreationFromForksAllowed != nil {
+		link.AutomaticCreationFromForksAllowed = *params.AutomaticCreationFromForksAllowed
+	}
+	link.UpdatedAt = time.Now()
+	writeJSON(w, http.StatusOK, map[string]*scalingo.SCMRepoLink{"scm_repo_link": link})
+}
+
+func (f *fakeAPI) handleSCMRepoLinkDelete(w http.ResponseWriter, r *http.Request) {
+	app := f.appFromRequest(w, r)
+	if app == nil {
+		return
+	}
+	if app.scmRepoLink == nil {
+		writeNotFound(w, "scm_repo_link")
+		return
+	}
+	app.scmRepoLink = nil
+	writeJSON(w, http.StatusNoContent, nil)
+}
+
+func (f *fakeAPI) handleDatabasesList(w http.ResponseWriter, _ *http.Request) {
+	databases := []scalingo.DatabaseNG{}
+	for _, app := range f.apps {
+		if app.databaseNG != nil {
+			databases = append(databases, *app.databaseNG)
+		}
+	}
+	writeJSON(w, http.StatusOK, scalingo.DatabasesListResponse{Databases: databases})
+}
+
+func (f *fakeAPI) handleDatabaseCreate(w http.ResponseWriter, r *http.Request) {
+	var params scalingo.DatabaseCreateParams
+	if !decodeBody(w, r, &params) {
+		return
+	}
+	provider := f.findAddonProvider(params.AddonProviderID)
+	if provider == nil {
+		writeNotFound(w, "addon_provider")
+		return
+	}
+	plan := findPlan(provider, params.PlanID)
+	if plan == nil {
+		writeNotFound(w, "plan")
+		return
+	}
+	if f.findApp(params.Name) != nil {
+		writeUnprocessable(w, "name", "has already been taken")
+		return
+	}
+
+	app := f.createApp(scalingo.AppsCreateOpts{Name: params.Name, ProjectID: params.ProjectID})
+	app.containers = nil
+	addon := f.provisionAddon(app, provider, plan)
+	app.databaseNG = &scalingo.DatabaseNG{
+		ID:         app.ID,
+		Name:       app.Name,
+		ProjectID:  params.ProjectID,
+		Technology: provider.ID,
+		Plan:       plan.Name,
+	}
+
+	res := *app.databaseNG
+	res.App = app.App
+	res.Database = f.databases[addon.ID].Database
+	res.Database.Status = scalingo.DatabaseStatusCreating
+	writeJSON(w, http.StatusCreated, scalingo.DatabaseCreateResponse{Database: res})
+}
+
+func (f *fakeAPI) findProject(id string) *scalingo.Project {
+	for _, project := range f.projects {
+		if project.ID == id {
+			return project
+		}
+	}
+	return nil
+}
+
+func (f *fakeAPI) handleProjectsList(w http.ResponseWriter, _ *http.Request) {
+	projects := []scalingo.Project{}
+	for _, project := range f.projects {
+		projects = append(projects, *project)
+	}
+	writeJSON(w, http.StatusOK, scalingo.ProjectsRes{Projects: projects})
+}
+
+func (f *fakeAPI) setDefaultProject(project *scalingo.Project) {
+	for _, other := range f.projects {
+		other.Default = false
+	}
+	project.Default = true
+}
+
+func (f *fakeAPI) handleProjectAdd(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Project scalingo.ProjectAddParams `json:"project"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	project := &scalingo.Project{
+		ID:        f.nextID("prj"),
+		Name:      payload.Project.Name,
+		Flags:     map[string]bool{},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+		Owner:     fakeOwner,
+	}
+	f.projects = append(f.projects, project)
+	if payload.Project.Default {
+		f.setDefaultProject(project)
+	}
+	writeJSON(w, http.StatusCreated, scalingo.ProjectRes{Project: *project})
+}
+
+func (f *fakeAPI) handleProjectGet(w http.ResponseWriter, r *http.Request) {
+	project := f.findProject(r.PathValue("id"))
+	if project == nil {
+		writeNotFound(w, "project")
+		return
+	}
+	writeJSON(w, http.StatusOK, scalingo.ProjectRes{Project: *project})
+}
+
+func (f *fakeAPI) handleProjectUpdate(w http.ResponseWriter, r *http.Request) {
+	project := f.findProject(r.PathValue("id"))
+	if project == nil {
+		writeNotFound(w, "project")
+		return
+	}
+	var payload struct {
+		Project scalingo.ProjectUpdateParams `json:"project"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	if payload.Project.Name != nil {
+		project.Name = *payload.Project.Name
+	}
+	if payload.Project.Default != nil {
+		if *payload.Project.Default {
+			f.setDefaultProject(project)
+		} else {
+			project.Default = false
+		}
+	}
+	project.UpdatedAt = time.Now()
+	writeJSON(w, http.StatusOK, scalingo.ProjectRes{Project: *project})
+}
+
+func (f *fakeAPI) handleProjectDelete(w http.ResponseWriter, r *http.Request) {
+	for i, project := range f.projects {
+		if project.ID != r.PathValue("id") {
+			continue
+		}
+		if project.Default {
+			writeUnprocessable(w, "project", "the default project cannot be deleted")
+			return
+		}
+		f.projects = append(f.projects[:i], f.projects[i+1:]...)
+		writeJSON(w, http.StatusNoContent, nil)
+		return
+	}
+	writeNotFound(w, "project")
+}
+
+func (f *fakeAPI) handleAddonProvidersList(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, scalingo.AddonProvidersListResponse{AddonProviders: f.addonProviders})
+}
+
+func (f *fakeAPI) handleAddonProviderPlansList(w http.ResponseWriter, r *http.Request) {
+	provider := f.findAddonProvider(r.PathValue("id"))
+	if provider == nil {
+		writeNotFound(w, "addon_provider")
+		return
+	}
+	plans := []*scalingo.Plan{}
+	for i := range provider.Plans {
+		plans = append(plans, &provider.Plans[i])
+	}
+	writeJSON(w, http.StatusOK, scalingo.AddonProviderPlansListResponse{Plans: plans})
+}
+
+func (f *fakeAPI) handleContainerSizesList(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]scalingo.ContainerSize{"container_sizes": f.containerSizes})
+}
+
+func (f *fakeAPI) handleStacksList(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]fakeStack{"stacks": f.stacks})
+}
+
+func (f *fakeAPI) handleNotificationPlatformsList(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, scalingo.PlatformsRes{NotificationPlatforms: f.notificationPlatforms})
+}
+
+func (f *fakeAPI) handleEventTypesList(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string][]scalingo.EventType{"event_types": f.eventTypes})
+}
+
+func (f *fakeAPI) handleInvoicesList(w http.ResponseWriter, r *http.Request) {
+	page, perPage := paginationParams(r.URL.Query())
+	data, meta := paginate(f.invoices, page, perPage)
+	writeJSON(w, http.StatusOK, map[string]any{
+		"invoices": data,
+		"meta":     map[string]any{"pagination": meta},
+	})
+}
+
+// Database API
+
+func (f *fakeAPI) dbRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/databases/{id}", f.handleDatabaseShow)
+	mux.HandleFunc("POST /api/databases/{id}/features", f.handleDatabaseEnableFeature)
+	mux.HandleFunc("DELETE /api/databases/{id}/features", f.handleDatabaseDisableFeature)
+	mux.HandleFunc("GET /api/databases/{id}/firewall_rules", f.handleFirewallRulesList)
+	mux.HandleFunc("POST /api/databases/{id}/firewall_rules", f.handleFirewallRulesCreate)
+	mux.HandleFunc("DELETE /api/databases/{id}/firewall_rules/{rule}", f.handleFirewallRulesDestroy)
+	mux.HandleFunc("GET /api/firewall/managed_ranges", f.handleFirewallManagedRanges)
+}
+
+func (f *fakeAPI) databaseFromRequest(w http.ResponseWriter, r *http.Request) *fakeDatabase {
+	db, ok := f.databases[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "database")
+		return nil
+	}
+	return db
+}
+
+func (f *fakeAPI) handleDatabaseShow(w http.ResponseWriter, r *http.Request) {
+	db := f.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	res := db.Database
+	res.Features = append([]scalingo.DatabaseFeature{}, db.Features...)
+	if db.pending > 0 {
+		db.pending--
+		res.Status = db.pendingStatus
+	}
+	// Pending features are activated once they have been observed.
+	for i := range db.Features {
+		if db.Features[i].Status == scalingo.DatabaseFeatureStatusPending {
+			db.Features[i].Status = scalingo.DatabaseFeatureStatusActivated
+		}
+	}
+	writeJSON(w, http.StatusOK, scalingo.DatabaseRes{Database: res})
+}
+
+func (f *fakeAPI) handleDatabaseEnableFeature(w http.ResponseWriter, r *http.Request) {
+	db := f.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	var payload scalingo.DatabaseEnableFeatureParams
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	feature := scalingo.DatabaseFeature{Name: payload.Feature.Name, Status: scalingo.DatabaseFeatureStatusPending}
+	db.Features = append(db.Features, feature)
+	writeJSON(w, http.StatusOK, scalingo.DatabaseEnableFeatureResponse{
+		Name: feature.Name, Status: feature.Status, Message: "feature is being enabled",
+	})
+}
+
+func (f *fakeAPI) handleDatabaseDisableFeature(w http.ResponseWriter, r *http.Request) {
+	db := f.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	name := r.URL.Query().Get("feature")
+	for i, feature := range db.Features {
+		if feature.Name == name {
+			db.Features = append(db.Features[:i], db.Features[i+1:]...)
+			writeJSON(w, http.StatusOK, scalingo.DatabaseDisableFeatureResponse{Message: "feature disabled"})
+			return
+		}
+	}
+	writeNotFound(w, "feature")
+}
+
+func (f *fakeAPI) handleFirewallRulesList(w http.ResponseWriter, r *http.Request) {
+	db := f.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	rules := []scalingo.FirewallRule{}
+	rules = append(rules, db.firewallRules...)
+	writeJSON(w, http.StatusOK, scalingo.FirewallRulesResponse{FirewallRules: rules})
+}
+
+func (f *fakeAPI) handleFirewallRulesCreate(w http.ResponseWriter, r *http.Request) {
+	db := f.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	var params scalingo.FirewallRuleCreateParams
+	if !decodeBody(w, r, &params) {
+		return
+	}
+	rule := scalingo.FirewallRule{
+		ID:         f.nextID("fw"),
+		Type:       params.Type,
+		CIDR:       params.CIDR,
+		Label:      params.Label,
+		RangeID:    params.RangeID,
+		DatabaseID: db.ID,
+	}
+	db.firewallRules = append(db.firewallRules, rule)
+	writeJSON(w, http.StatusCreated, scalingo.FirewallRuleResponse{FirewallRule: rule})
+}
+
+func (f *fakeAPI) handleFirewallRulesDestroy(w http.ResponseWriter, r *http.Request) {
+	db := f.databaseFromRequest(w, r)
+	if db == nil {
+		return
+	}
+	for i, rule := range db.firewallRules {
+		if rule.ID == r.PathValue("rule") {
+			db.firewallRules = append(db.firewallRules[:i], db.firewallRules[i+1:]...)
+			writeJSON(w, http.StatusNoContent, nil)
+			return
+		}
+	}
+	writeNotFound(w, "firewall_rule")
+}
+
+func (f *fakeAPI) handleFirewallManagedRanges(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, scalingo.FirewallManagedRangesResponse{ManagedRanges: f.managedRanges})
+}
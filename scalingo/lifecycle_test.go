@@ -0,0 +1,266 @@
+package scalingo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// The lifecycle helpers below drive resources and data sources the way
+// Terraform core does (validate, plan, apply, refresh, import), without
+// requiring a Terraform binary. They are meant to be used with the fake API
+// from fake_api_test.go.
+
+func init() {
+	// The fake API settles asynchronous objects after a few polls, there is no
+	// need to wait between them.
+	defaultWaitInterval = 10 * time.Millisecond
+	retryBaseDelay = time.Millisecond
+}
+
+// newTestProvider starts a fake API and returns it along with the meta of a
+// provider configured to talk to it.
+func newTestProvider(t *testing.T) (*fakeAPI, any) {
+	t.Helper()
+	return newTestProviderWithConfig(t, nil)
+}
+
+func newTestProviderWithConfig(t *testing.T, config map[string]any) (*fakeAPI, any) {
+	t.Helper()
+
+	f := newFakeAPI(t)
+	raw := map[string]any{
+		"api_token":    "tk-us-fake",
+		"api_url":      f.api.URL,
+		"db_api_url":   f.db.URL,
+		"auth_api_url": f.auth.URL,
+		"region":       f.region,
+	}
+	for k, v := range config {
+		raw[k] = v
+	}
+
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		t.Fatalf("configure provider: %v", diags)
+	}
+	return f, p.Meta()
+}
+
+// testTerraformPreCheck skips the tests running the Terraform CLI through
+// resource.UnitTest when it isn't installed: the SDK would otherwise download
+// it, or exit the whole test binary if it can't.
+func testTerraformPreCheck(t *testing.T) {
+	t.Helper()
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("terraform not found in PATH; skipping the tests running the Terraform CLI")
+	}
+}
+
+// testProviderConfig returns the configuration of a provider talking to the
+// fake API, to be prepended to the configurations given to resource.UnitTest.
+func testProviderConfig(f *fakeAPI) string {
+	return fmt.Sprintf(`
+provider "scalingo" {
+  api_token    = "tk-us-fake"
+  api_url      = %q
+  db_api_url   = %q
+  auth_api_url = %q
+  region       = %q
+}
+`, f.api.URL, f.db.URL, f.auth.URL, f.region)
+}
+
+func testResource(t *testing.T, name string) *schema.Resource {
+	t.Helper()
+	r, ok := Provider().ResourcesMap[name]
+	if !ok {
+		t.Fatalf("unknown resource %s", name)
+	}
+	return r
+}
+
+func testDataSource(t *testing.T, name string) *schema.Resource {
+	t.Helper()
+	r, ok := Provider().DataSourcesMap[name]
+	if !ok {
+		t.Fatalf("unknown data source %s", name)
+	}
+	return r
+}
+
+// planResource validates the configuration and computes the diff against the
+// given state, nil meaning the resource doesn't exist yet.
+func planResource(t *testing.T, name string, meta any, state *terraform.InstanceState, raw map[string]any) (*terraform.InstanceDiff, diag.Diagnostics) {
+	t.Helper()
+	r := testResource(t, name)
+	config := terraform.NewResourceConfigRaw(raw)
+
+	diags := r.Validate(config)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	diff, err := r.Diff(context.Background(), state, config, meta)
+	if err != nil {
+		return nil, append(diags, diag.FromErr(err)...)
+	}
+	return diff, diags
+}
+
+// applyResource plans and applies the configuration, returning the new state.
+// The given state is returned untouched when the plan is empty.
+func applyResource(t *testing.T, name string, meta any, state *terraform.InstanceState, raw map[string]any) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+	diff, diags := planResource(t, name, meta, state, raw)
+	if diags.HasError() || diff == nil || diff.Empty() {
+		return state, diags
+	}
+
+	newState, applyDiags := testResource(t, name).Apply(context.Background(), state, diff, meta)
+	return newState, append(diags, applyDiags...)
+}
+
+func mustApplyResource(t *testing.T, name string, meta any, state *terraform.InstanceState, raw map[string]any) *terraform.InstanceState {
+	t.Helper()
+	newState, diags := applyResource(t, name, meta, state, raw)
+	if diags.HasError() {
+		t.Fatalf("apply %s: %v", name, diags)
+	}
+	if newState == nil || newState.ID == "" {
+		t.Fatalf("apply %s: resource has no ID", name)
+	}
+	return newState
+}
+
+// refreshResource reads the resource, nil is returned when it is gone.
+func refreshResource(t *testing.T, name string, meta any, state *terraform.InstanceState) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+	return testResource(t, name).RefreshWithoutUpgrade(context.Background(), state, meta)
+}
+
+func mustRefreshResource(t *testing.T, name string, meta any, state *terraform.InstanceState) *terraform.InstanceState {
+	t.Helper()
+	newState, diags := refreshResource(t, name, meta, state)
+	if diags.HasError() {
+		t.Fatalf("refresh %s: %v", name, diags)
+	}
+	return newState
+}
+
+func destroyResource(t *testing.T, name string, meta any, state *terraform.InstanceState) diag.Diagnostics {
+	t.Helper()
+	_, diags := testResource(t, name).Apply(context.Background(), state, &terraform.InstanceDiff{Destroy: true}, meta)
+	return diags
+}
+
+func mustDestroyResource(t *testing.T, name string, meta any, state *terraform.InstanceState) {
+	t.Helper()
+	diags := destroyResource(t, name, meta, state)
+	if diags.HasError() {
+		t.Fatalf("destroy %s: %v", name, diags)
+	}
+}
+
+// importResource runs the importer with the given import ID then refreshes
+// the imported resource, as `terraform import` does.
+func importResource(t *testing.T, name string, meta any, id string) (*terraform.InstanceState, error) {
+	t.Helper()
+	r := testResource(t, name)
+	ctx := context.Background()
+
+	data, err := r.Importer.StateContext(ctx, r.Data(&terraform.InstanceState{ID: id}), meta)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != 1 {
+		t.Fatalf("import %s: expected 1 resource, got %d", name, len(data))
+	}
+	// Terraform refuses to import an object which has been removed from the
+	// state by the importer.
+	if data[0].Id() == "" {
+		return nil, fmt.Errorf("cannot import non-existent remote object %s", id)
+	}
+
+	state, diags := r.RefreshWithoutUpgrade(ctx, data[0].State(), meta)
+	if diags.HasError() {
+		return nil, DiagnosticError(diags)
+	}
+	return state, nil
+}
+
+func mustImportResource(t *testing.T, name string, meta any, id string) *terraform.InstanceState {
+	t.Helper()
+	state, err := importResource(t, name, meta, id)
+	if err != nil {
+		t.Fatalf("import %s: %v", name, err)
+	}
+	if state == nil {
+		t.Fatalf("import %s: resource not found", name)
+	}
+	return state
+}
+
+// readDataSource reads a data source with the given configuration.
+func readDataSource(t *testing.T, name string, meta any, raw map[string]any) (*terraform.InstanceState, diag.Diagnostics) {
+	t.Helper()
+	r := testDataSource(t, name)
+	ctx := context.Background()
+	config := terraform.NewResourceConfigRaw(raw)
+
+	diags := r.Validate(config)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	diff, err := r.Diff(ctx, nil, config, meta)
+	if err != nil {
+		return nil, append(diags, diag.FromErr(err)...)
+	}
+	state, readDiags := r.ReadDataApply(ctx, diff, meta)
+	return state, append(diags, readDiags...)
+}
+
+func mustReadDataSource(t *testing.T, name string, meta any, raw map[string]any) *terraform.InstanceState {
+	t.Helper()
+	state, diags := readDataSource(t, name, meta, raw)
+	if diags.HasError() {
+		t.Fatalf("read %s: %v", name, diags)
+	}
+	return state
+}
+
+func assertAttr(t *testing.T, state *terraform.InstanceState, key, expected string) {
+	t.Helper()
+	if state == nil {
+		t.Fatalf("%s: state is nil", key)
+	}
+	if actual := state.Attributes[key]; actual != expected {
+		t.Errorf("%s: expected %q, got %q", key, expected, actual)
+	}
+}
+
+func assertDiagContains(t *testing.T, diags diag.Diagnostics, substr string) {
+	t.Helper()
+	if !diags.HasError() {
+		t.Fatalf("expected an error containing %q, got none", substr)
+	}
+	for _, d := range diags {
+		if d.Severity == diag.Error && (strings.Contains(d.Summary, substr) || strings.Contains(d.Detail, substr)) {
+			return
+		}
+	}
+	t.Errorf("expected an error containing %q, got %v", substr, diags)
+}
@@ -5,11 +5,13 @@ package scalingo
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/Scalingo/go-scalingo/v11"
+	httpclient "github.com/Scalingo/go-scalingo/v11/http"
 )
 
 func Provider() *schema.Provider {
@@ -45,11 +47,29 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("SCALINGO_REGION", "osc-fr1"),
 				Description: "Region to use with the provider. Can also be sourced from `SCALINGO_REGION`. (default: `osc-fr1`)",
 			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SCALINGO_MAX_RETRIES", defaultMaxRetries),
+				Description: "Maximum number of times a request failing with a transient error (5xx answer to an idempotent request, 429 answer) is retried, `0` disables retries. Can also be sourced from `SCALINGO_MAX_RETRIES`. (default: `5`)",
+			},
+			"retry_max_wait": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SCALINGO_RETRY_MAX_WAIT", defaultRetryMaxWait),
+				Description: "Maximum duration to wait between two attempts of a request, as a Go duration (e.g. `45s`). It also caps the delay asked by the API with the `Retry-After` header. Can also be sourced from `SCALINGO_RETRY_MAX_WAIT`. (default: `30s`)",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"scalingo_addon_providers":                 dataSourceScAddonProvider(),
+			"scalingo_app":                             dataSourceScApp(),
+			"scalingo_app_containers":                  dataSourceScAppContainers(),
+			"scalingo_app_stats":                       dataSourceScAppStats(),
+			"scalingo_apps":                            dataSourceScApps(),
 			"scalingo_container_size":                  dataSourceScContainerSize(),
+			"scalingo_cron_tasks":                      dataSourceScCronTasks(),
 			"scalingo_database_firewall_managed_range": dataSourceScDatabaseFirewallManagedRange(),
+			"scalingo_events":                          dataSourceScEvents(),
 			"scalingo_invoices":                        dataSourceScInvoice(),
 			"scalingo_notification_platform":           dataSourceScNotificationPlatform(),
 			"scalingo_private_network_domain":          dataSourceScPrivateNetworkDomain(),
@@ -58,28 +78,56 @@ func Provider() *schema.Provider {
 			"scalingo_scm_integration":                 dataSourceScScmIntegration(),
 			"scalingo_stack":                           dataSourceScStack(),
 		},
-		ResourcesMap: map[string]*schema.Resource{
+		ResourcesMap: withOrphaningTransferCheck(map[string]*schema.Resource{
 			"scalingo_addon":                  resourceScalingoAddon(),
 			"scalingo_alert":                  resourceScalingoAlert(),
 			"scalingo_app":                    resourceScalingoApp(),
+			"scalingo_app_formation":          resourceScalingoAppFormation(),
+			"scalingo_app_restart":            resourceScalingoAppRestart(),
+			"scalingo_app_source":             resourceScalingoAppSource(),
 			"scalingo_autoscaler":             resourceScalingoAutoscaler(),
 			"scalingo_collaborator":           resourceScalingoCollaborator(),
 			"scalingo_container_type":         resourceScalingoContainerType(),
 			"scalingo_database":               resourceScalingoDatabase(),
 			"scalingo_database_firewall_rule": resourceScalingoDatabaseFirewallRule(),
+			"scalingo_deployment":             resourceScalingoDeployment(),
 			"scalingo_domain":                 resourceScalingoDomain(),
+			"scalingo_environment_variable":   resourceScalingoEnvironmentVariable(),
 			"scalingo_log_drain":              resourceScalingoLogDrain(),
 			"scalingo_notifier":               resourceScalingoNotifier(),
+			"scalingo_one_off":                resourceScalingoOneOff(),
 			"scalingo_project":                resourceScalingoProject(),
 			"scalingo_scm_integration":        resourceScalingoScmIntegration(),
 			"scalingo_scm_repo_link":          resourceScalingoScmRepoLink(),
 			"scalingo_ssh_key":                resourceScalingoSSHKey(),
-		},
+		}),
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
+// providerMeta is the meta of the provider, shared by all its resources and
+// data sources. It embeds the Scalingo client so that it can be used
+// directly to query the APIs.
+type providerMeta struct {
+	*scalingo.Client
+
+	catalog   *catalog
+	appLocks  appLocks
+	variables variableOwners
+	transfers appTransfers
+}
+
 func providerConfigure(ctx context.Context, data *schema.ResourceData) (any, diag.Diagnostics) {
+	maxRetries, _ := data.Get("max_retries").(int)
+	if maxRetries < 0 {
+		return nil, diag.Errorf("invalid max_retries: must not be negative, got %d", maxRetries)
+	}
+	rawRetryMaxWait, _ := data.Get("retry_max_wait").(string)
+	retryMaxWait, err := time.ParseDuration(rawRetryMaxWait)
+	if err != nil {
+		return nil, diag.Errorf("invalid retry_max_wait: %v", err)
+	}
+
 	client, err := scalingo.New(ctx, providerClientConfig(data))
 	if err != nil {
 		return nil, diag.Diagnostics{
@@ -90,7 +138,30 @@ func providerConfigure(ctx context.Context, data *schema.ResourceData) (any, dia
 		}
 	}
 
-	return client, nil
+	// The HTTP clients of the Scalingo and Authentication APIs are built once and
+	// cached by go-scalingo. The Database API one is built for every request
+	// and can't be configured, its requests are not retried.
+	setupRetries(ctx, client.ScalingoAPI().HTTPClient(), maxRetries, retryMaxWait)
+	setupRetries(ctx, client.AuthAPI().HTTPClient(), maxRetries, retryMaxWait)
+
+	// The token generators of go-scalingo cache the access token without
+	// synchronization: fetching it here, before the resources are planned and
+	// applied in parallel, prevents them from exchanging it concurrently. It
+	// is only exchanged again a few minutes before its expiration.
+	for _, api := range []httpclient.Client{client.ScalingoAPI(), client.AuthAPI()} {
+		if !api.IsAuthenticatedClient() {
+			continue
+		}
+		_, err = api.TokenGenerator().GetAccessToken(ctx)
+		if err != nil {
+			return nil, diag.Errorf("get access token: %v", err)
+		}
+	}
+
+	return &providerMeta{
+		Client:  client,
+		catalog: newCatalog(client),
+	}, nil
 }
 
 func providerClientConfig(data *schema.ResourceData) scalingo.ClientConfig {
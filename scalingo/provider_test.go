@@ -1,9 +1,12 @@
 package scalingo
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
 )
 
 func TestProvider(t *testing.T) {
@@ -29,3 +32,18 @@ func TestProviderClientConfigSetsUserAgent(t *testing.T) {
 		t.Fatalf("unexpected user agent: %q", config.UserAgent)
 	}
 }
+
+func TestProviderConfigure_FetchesAccessToken(t *testing.T) {
+	f, meta := newTestProvider(t)
+	exchanges := f.requestCount(http.MethodPost, "/v1/tokens/exchange")
+	if exchanges == 0 {
+		t.Fatal("expected the access token to be fetched by the configuration of the provider")
+	}
+
+	// The resources reuse it instead of exchanging it concurrently.
+	f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	mustReadDataSource(t, "scalingo_apps", meta, map[string]any{})
+	if count := f.requestCount(http.MethodPost, "/v1/tokens/exchange"); count != exchanges {
+		t.Errorf("expected the access token to be reused, got %d more exchanges", count-exchanges)
+	}
+}
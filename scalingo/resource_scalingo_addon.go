@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -11,13 +12,22 @@ import (
 	"github.com/Scalingo/go-scalingo/v11"
 )
 
+// addonProvisioningTimeout is the default timeout of the provisioning and
+// of the plan changes of an addon.
+const addonProvisioningTimeout = 20 * time.Minute
+
 func resourceScalingoAddon() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceAddonCreate,
 		ReadContext:   resourceAddonRead,
 		UpdateContext: resourceAddonUpdate,
 		DeleteContext: resourceAddonDelete,
+		CustomizeDiff: resourceAddonCustomizeDiff,
 		Description:   "Resource representing an Addon attached to an Application based on an AddonProvider",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(addonProvisioningTimeout),
+			Update: schema.DefaultTimeout(addonProvisioningTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"provider_id": {
@@ -65,12 +75,18 @@ func resourceScalingoAddon() *schema.Resource {
 }
 
 func resourceAddonCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	providerID, _ := d.Get("provider_id").(string)
 	planName, _ := d.Get("plan").(string)
 	appID, _ := d.Get("app").(string)
 
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	planID, err := addonPlanID(ctx, client, providerID, planName)
 	if err != nil {
 		return diag.Errorf("get addon plan id: %v", err)
@@ -88,7 +104,7 @@ func resourceAddonCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		return diag.Errorf("provision addon: %v", err)
 	}
 
-	err = waitUntilProvisioned(ctx, client, res.Addon)
+	err = waitUntilProvisioned(ctx, client, res.Addon, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return diag.Errorf("wait for addon to be provisioned: %v", err)
 	}
@@ -105,7 +121,7 @@ func resourceAddonCreate(ctx context.Context, d *schema.ResourceData, meta inter
 		if err != nil {
 			return diag.Errorf("add feature on database addon id: %v", err)
 		}
-		err = waitUntilDatabaseFeatureActivated(ctx, client, res.Addon, featureStr)
+		err = waitUntilDatabaseFeatureActivated(ctx, client, res.Addon, featureStr, d.Timeout(schema.TimeoutCreate))
 		if err != nil {
 			return diag.Errorf("activate feature on database addon id: %v", err)
 		}
@@ -115,14 +131,14 @@ func resourceAddonCreate(ctx context.Context, d *schema.ResourceData, meta inter
 }
 
 func resourceAddonRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 
 	addon, err := client.AddonShow(ctx, appID, d.Id())
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			d.MarkNewResource()
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_addon")
 			return nil
 		}
 		return diag.Errorf("get addon details: %v", err)
@@ -138,7 +154,7 @@ func resourceAddonRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diag.Errorf("store addon information: %v", err)
 	}
 
-	providers, err := client.AddonProvidersList(ctx)
+	providers, err := client.catalog.AddonProviders(ctx)
 	if err != nil {
 		return diag.Errorf("list addon providers: %v", err)
 	}
@@ -174,11 +190,17 @@ func addonIsDatabase(providers []*scalingo.AddonProvider, addon scalingo.Addon)
 }
 
 func resourceAddonUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 	providerID, _ := d.Get("provider_id").(string)
 
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	addon, err := client.AddonShow(ctx, appID, d.Id())
 	if err != nil {
 		return diag.Errorf("get addon information for %v: %v", d.Id(), err)
@@ -197,7 +219,7 @@ func resourceAddonUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 			return diag.Errorf("upgrade addon: %v", err)
 		}
 
-		err = waitUntilProvisioned(ctx, client, res.Addon)
+		err = waitUntilProvisioned(ctx, client, res.Addon, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return diag.Errorf("wait for addon to be provisioned: %v", err)
 		}
@@ -215,7 +237,7 @@ func resourceAddonUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 		}
 		databaseFeatures, _ := d.Get("database_features").([]interface{})
 
-		err = compareAndApplyDatabaseFeatures(ctx, client, addon, db, databaseFeatures)
+		err = compareAndApplyDatabaseFeatures(ctx, client, addon, db, databaseFeatures, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return diag.Errorf("compare and apply database features of %v: %v", addon.ID, err)
 		}
@@ -224,7 +246,7 @@ func resourceAddonUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	return nil
 }
 
-func compareAndApplyDatabaseFeatures(ctx context.Context, client *scalingo.Client, addon scalingo.Addon, db scalingo.Database, databaseFeatures []interface{}) error {
+func compareAndApplyDatabaseFeatures(ctx context.Context, client *providerMeta, addon scalingo.Addon, db scalingo.Database, databaseFeatures []interface{}, timeout time.Duration) error {
 	featuresToAdd := []string{}
 	featuresToRemove := []string{}
 
@@ -257,7 +279,7 @@ func compareAndApplyDatabaseFeatures(ctx context.Context, client *scalingo.Clien
 		if err != nil {
 			return fmt.Errorf("enable database feature for addon %v: %v", addon.ID, feature)
 		}
-		err = waitUntilDatabaseFeatureActivated(ctx, client, addon, feature)
+		err = waitUntilDatabaseFeatureActivated(ctx, client, addon, feature, timeout)
 		if err != nil {
 			return fmt.Errorf("wait until database feature '%v' is enabled %v: %v", feature, addon.ID, err)
 		}
@@ -273,11 +295,17 @@ func compareAndApplyDatabaseFeatures(ctx context.Context, client *scalingo.Clien
 }
 
 func resourceAddonDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 
-	err := client.AddonDestroy(ctx, appID, d.Id())
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	err = client.AddonDestroy(ctx, appID, d.Id())
 	if err != nil {
 		return diag.Errorf("destroy addon: %v", err)
 	}
@@ -285,8 +313,8 @@ func resourceAddonDelete(ctx context.Context, d *schema.ResourceData, meta inter
 	return nil
 }
 
-func addonPlanID(ctx context.Context, client *scalingo.Client, providerID, name string) (string, error) {
-	plans, err := client.AddonProviderPlansList(ctx, providerID, scalingo.AddonProviderPlansListOpts{})
+func addonPlanID(ctx context.Context, client *providerMeta, providerID, name string) (string, error) {
+	plans, err := client.catalog.AddonProviderPlans(ctx, providerID)
 	if err != nil {
 		return "", err
 	}
@@ -303,6 +331,15 @@ func addonPlanID(ctx context.Context, client *scalingo.Client, providerID, name
 	return "", fmt.Errorf("Invalid plan name, possible values are: %s", planList)
 }
 
+func resourceAddonCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !shouldValidate(d, "plan") || !d.NewValueKnown("provider_id") {
+		return nil
+	}
+	client, _ := meta.(*providerMeta)
+
+	return validateAddonPlan(ctx, client, d.Get("provider_id").(string), d.Get("plan").(string))
+}
+
 func resourceAddonImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	ids := strings.Split(d.Id(), ":")
 	if len(ids) != 2 {
@@ -322,9 +359,15 @@ func resourceAddonImport(ctx context.Context, d *schema.ResourceData, meta inter
 	return []*schema.ResourceData{d}, nil
 }
 
-func waitUntilProvisioned(ctx context.Context, client *scalingo.Client, addon scalingo.Addon) error {
+func waitUntilProvisioned(ctx context.Context, client *providerMeta, addon scalingo.Addon, timeout time.Duration) error {
 	var err error
-	return waitUntil(ctx, waitOptions{}, func() (bool, error) {
+	return waitUntil(ctx, waitOptions{
+		timeout:   timeout,
+		operation: fmt.Sprintf("addon %v to be provisioned", addon.ID),
+		status: func() string {
+			return string(addon.Status)
+		},
+	}, func() (bool, error) {
 		addon, err = client.AddonShow(ctx, addon.AppID, addon.ID)
 		if err != nil {
 			return false, err
@@ -336,13 +379,23 @@ func waitUntilProvisioned(ctx context.Context, client *scalingo.Client, addon sc
 	})
 }
 
-func waitUntilDatabaseFeatureActivated(ctx context.Context, client *scalingo.Client, addon scalingo.Addon, feature string) error {
-	return waitUntil(ctx, waitOptions{}, func() (bool, error) {
+func waitUntilDatabaseFeatureActivated(ctx context.Context, client *providerMeta, addon scalingo.Addon, feature string, timeout time.Duration) error {
+	var status scalingo.DatabaseFeatureStatus
+	return waitUntil(ctx, waitOptions{
+		timeout:   timeout,
+		operation: fmt.Sprintf("feature %v of addon %v to be activated", feature, addon.ID),
+		status: func() string {
+			return string(status)
+		},
+	}, func() (bool, error) {
 		db, err := client.DatabaseShow(ctx, addon.AppID, addon.ID)
 		if err != nil {
 			return false, fmt.Errorf("refresh database metadata: %w", err)
 		}
 		for _, f := range db.Features {
+			if f.Name == feature {
+				status = f.Status
+			}
 			if f.Name == feature && f.Status != scalingo.DatabaseFeatureStatusPending {
 				switch f.Status {
 				case scalingo.DatabaseFeatureStatusActivated:
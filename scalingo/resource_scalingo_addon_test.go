@@ -0,0 +1,103 @@
+package scalingo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceAddon_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.pendingPolls = 2
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_addon", meta, nil, map[string]any{
+		"app":               app.ID,
+		"provider_id":       "postgresql",
+		"plan":              "postgresql-starter-512",
+		"database_features": []any{"force-ssl"},
+	})
+	if len(app.addons) != 1 {
+		t.Fatalf("expected an addon to be provisioned, got %d", len(app.addons))
+	}
+	addon := app.addons[0]
+	if state.ID != addon.ID {
+		t.Errorf("expected ID %s, got %s", addon.ID, state.ID)
+	}
+	assertAttr(t, state, "plan_id", "pl-pg-512")
+	assertAttr(t, state, "resource_id", addon.ResourceID)
+	// The addon stays in the provisioning state for a few polls.
+	if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID+"/addons/"+addon.ID); count < 3 {
+		t.Errorf("expected the addon status to be polled until running, got %d requests", count)
+	}
+
+	state = mustRefreshResource(t, "scalingo_addon", meta, state)
+	assertAttr(t, state, "plan", "postgresql-starter-512")
+	assertAttr(t, state, "database_features.#", "1")
+	assertAttr(t, state, "database_features.0", "force-ssl")
+
+	state = mustApplyResource(t, "scalingo_addon", meta, state, map[string]any{
+		"app":               app.ID,
+		"provider_id":       "postgresql",
+		"plan":              "postgresql-starter-1024",
+		"database_features": []any{},
+	})
+	assertAttr(t, state, "plan_id", "pl-pg-1024")
+	if addon.Plan.ID != "pl-pg-1024" {
+		t.Errorf("expected the addon to be upgraded, got plan %s", addon.Plan.ID)
+	}
+	if features := f.databases[addon.ID].Features; len(features) != 0 {
+		t.Errorf("expected the database features to be disabled, got %v", features)
+	}
+
+	mustDestroyResource(t, "scalingo_addon", meta, state)
+	if len(app.addons) != 0 {
+		t.Error("addon has not been destroyed")
+	}
+}
+
+func TestResourceAddon_InvalidPlan(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	_, diags := applyResource(t, "scalingo_addon", meta, nil, map[string]any{
+		"app":         app.ID,
+		"provider_id": "mailjet",
+		"plan":        "premium",
+	})
+	assertDiagContains(t, diags, `"premium" is not a valid plan for addon provider mailjet. Possible values are: free, basic`)
+	if len(app.addons) != 0 {
+		t.Error("no addon should have been provisioned")
+	}
+}
+
+func TestResourceAddon_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	addon := f.provisionAddon(app, f.findAddonProvider("mailjet"), findPlan(f.findAddonProvider("mailjet"), "pl-mj-free"))
+
+	state := mustImportResource(t, "scalingo_addon", meta, app.ID+":"+addon.ID)
+	assertAttr(t, state, "app", app.ID)
+	assertAttr(t, state, "provider_id", "mailjet")
+	assertAttr(t, state, "plan", "free")
+
+	_, err := importResource(t, "scalingo_addon", meta, addon.ID)
+	if err == nil {
+		t.Error("expected an error for an import ID without application")
+	}
+}
+
+func TestResourceAddon_ProvisioningTimeout(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.pendingPolls = 1000
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	_, diags := applyResource(t, "scalingo_addon", meta, nil, map[string]any{
+		"app":         app.ID,
+		"provider_id": "mailjet",
+		"plan":        "free",
+		"timeouts":    map[string]any{"create": "50ms"},
+	})
+	assertDiagContains(t, diags, "to be provisioned (last status: provisioning)")
+}
@@ -18,6 +18,7 @@ func resourceScalingoAlert() *schema.Resource {
 		ReadContext:   resourceAlertsRead,
 		UpdateContext: resourceAlertsUpdate,
 		DeleteContext: resourceAlertsDelete,
+		CustomizeDiff: resourceAlertsCustomizeDiff,
 		Description:   "Resource representing an alert definition for an application",
 
 		Schema: map[string]*schema.Schema{
@@ -34,7 +35,7 @@ func resourceScalingoAlert() *schema.Resource {
 			"metric": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Metric (cpu/ram/swap/rpm/...) monitored by the alert",
+				Description: "Metric (cpu/memory/swap/5XX/all/servers_amount/rpm_per_container/p95_response_time) monitored by the alert",
 			},
 			"limit": {
 				Type:        schema.TypeFloat,
@@ -80,14 +81,26 @@ func resourceScalingoAlert() *schema.Resource {
 	}
 }
 
+func resourceAlertsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !shouldValidate(d, "metric") {
+		return nil
+	}
+	return validateValue("metric", d.Get("metric").(string), metrics)
+}
+
 func resourceAlertsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 	app, _ := d.Get("app").(string)
 
+	unlock, err := client.lockApp(ctx, app)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	var (
 		remindEvery           time.Duration
 		durationBeforeTrigger time.Duration
-		err                   error
 	)
 
 	params := scalingo.AlertAddParams{
@@ -135,17 +148,25 @@ func resourceAlertsCreate(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceAlertsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	app, _ := d.Get("app").(string)
 
 	alerts, err := client.AlertsList(ctx, app)
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_alert")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 	filteredAlerts := keepIf(alerts, func(a *scalingo.Alert) bool {
 		return a.ID == d.Id()
 	})
+	if len(filteredAlerts) == 0 {
+		removeFromState(ctx, d, "scalingo_alert")
+		return nil
+	}
 	if len(filteredAlerts) != 1 {
 		return diag.Errorf("fail to get alerts information: %v", err)
 	}
@@ -170,11 +191,17 @@ func resourceAlertsRead(ctx context.Context, d *schema.ResourceData, meta interf
 }
 
 func resourceAlertsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	id := d.Id()
 	app, _ := d.Get("app").(string)
 
+	unlock, err := client.lockApp(ctx, app)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	alertUpdateParams := scalingo.AlertUpdateParams{}
 	changed := false
 	if d.HasChange("container_type") {
@@ -241,11 +268,17 @@ func resourceAlertsUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceAlertsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	app, _ := d.Get("app").(string)
 
-	err := client.AlertRemove(ctx, app, d.Id())
+	unlock, err := client.lockApp(ctx, app)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	err = client.AlertRemove(ctx, app, d.Id())
 	if err != nil {
 		return diag.Errorf("fail to delete alert: %v", err)
 	}
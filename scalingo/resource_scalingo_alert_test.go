@@ -0,0 +1,93 @@
+package scalingo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceAlert_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_alert", meta, nil, map[string]any{
+		"app":                     app.ID,
+		"container_type":          "web",
+		"metric":                  "cpu",
+		"limit":                   0.8,
+		"duration_before_trigger": "5m",
+		"notifiers":               []any{"no-1"},
+	})
+	if len(app.alerts) != 1 {
+		t.Fatalf("expected an alert to be created, got %d", len(app.alerts))
+	}
+	alert := app.alerts[0]
+	if state.ID != alert.ID {
+		t.Errorf("expected ID %s, got %s", alert.ID, state.ID)
+	}
+	if alert.DurationBeforeTrigger != 5*time.Minute {
+		t.Errorf("expected a 5m trigger duration, got %v", alert.DurationBeforeTrigger)
+	}
+
+	state = mustRefreshResource(t, "scalingo_alert", meta, state)
+	assertAttr(t, state, "metric", "cpu")
+	assertAttr(t, state, "limit", "0.8")
+	assertAttr(t, state, "duration_before_trigger", "5m0s")
+	assertAttr(t, state, "notifiers.0", "no-1")
+
+	// durations are compared semantically
+	diff, diags := planResource(t, "scalingo_alert", meta, state, map[string]any{
+		"app":                     app.ID,
+		"container_type":          "web",
+		"metric":                  "cpu",
+		"limit":                   0.8,
+		"duration_before_trigger": "300s",
+		"notifiers":               []any{"no-1"},
+	})
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected an empty plan, got %v", diff)
+	}
+
+	state = mustApplyResource(t, "scalingo_alert", meta, state, map[string]any{
+		"app":            app.ID,
+		"container_type": "web",
+		"metric":         "memory",
+		"limit":          0.9,
+		"disabled":       true,
+		"notifiers":      []any{},
+	})
+	assertAttr(t, state, "metric", "memory")
+	assertAttr(t, state, "disabled", "true")
+	if alert.Metric != "memory" || alert.Limit != 0.9 || !alert.Disabled {
+		t.Errorf("alert has not been updated: %+v", alert)
+	}
+
+	mustDestroyResource(t, "scalingo_alert", meta, state)
+	if len(app.alerts) != 0 {
+		t.Error("alert has not been destroyed")
+	}
+}
+
+func TestResourceAlert_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	created := mustApplyResource(t, "scalingo_alert", meta, nil, map[string]any{
+		"app":            app.ID,
+		"container_type": "web",
+		"metric":         "rpm_per_container",
+		"limit":          1000,
+	})
+
+	state := mustImportResource(t, "scalingo_alert", meta, app.ID+":"+created.ID)
+	assertAttr(t, state, "app", app.ID)
+	assertAttr(t, state, "metric", "rpm_per_container")
+
+	_, err := importResource(t, "scalingo_alert", meta, created.ID)
+	if err == nil {
+		t.Error("expected an error for an import ID without application")
+	}
+}
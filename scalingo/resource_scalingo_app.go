@@ -2,7 +2,11 @@ package scalingo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -11,13 +15,22 @@ import (
 	"github.com/Scalingo/go-scalingo/v11"
 )
 
+// appOperationTimeout is the default timeout of the creation and of the
+// updates of an application, which may wait for the application to restart.
+const appOperationTimeout = 10 * time.Minute
+
 func resourceScalingoApp() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceAppCreate,
 		ReadContext:   resourceAppRead,
 		UpdateContext: resourceAppUpdate,
 		DeleteContext: resourceAppDelete,
+		CustomizeDiff: resourceAppCustomizeDiff,
 		Description:   "Resource representing an application",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(appOperationTimeout),
+			Update: schema.DefaultTimeout(appOperationTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -30,9 +43,35 @@ func resourceScalingoApp() *schema.Resource {
 				Optional:    true,
 				Description: "Key-value map of environment variables attached to the application",
 			},
-			"all_environment": {
+			"sensitive_environment": {
 				Type:        schema.TypeMap,
-				Computed:    true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Key-value map of environment variables attached to the application whose values are hidden in the plan and logs, such as passwords and API keys",
+			},
+			"restart_on_environment_change": {
+				Type: schema.TypeBool,
+				// Without default, the states of the applications imported or
+				// created by a previous version don't differ from the
+				// configurations leaving it unset.
+				Optional:    true,
+				Description: "Restart the containers of the application when its environment changes (default: true)",
+			},
+			"restart_container_types": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateContainerTypeName,
+				},
+				Description: "Container types restarted when the environment changes, e.g. `[\"web\", \"worker\"]`, all of them if unset. Ignored if `restart_on_environment_change` is false",
+			},
+			"all_environment": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				// It contains the sensitive environment as well as the
+				// credentials of the addons.
+				Sensitive:   true,
 				Description: "Computed key-value map containing environment in read-only",
 			},
 			"base_url": {
@@ -76,6 +115,13 @@ func resourceScalingoApp() *schema.Resource {
 				Computed:    true,
 				Description: "ID of the base stack to use (scalingo-18/scalingo-20/scalingo-22)",
 			},
+			"redeploy_on_stack_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Description: "Deploy the application again when `stack_id` changes so that it runs on the new stack, otherwise the new stack is only used by the next deployment. " +
+					"The git reference of the last successful deployment is deployed from the archive of the linked GitHub or GitLab repository, which must be downloadable without credentials: private repositories aren't supported. " +
+					"The stack isn't changed if the application isn't linked to a repository or its last deployment has no git reference, and the next apply tries again if the deployment fails",
+			},
 			"project_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -88,6 +134,32 @@ func resourceScalingoApp() *schema.Resource {
 				Default:     false,
 				Description: "Whether the application should be an HDS resource",
 			},
+			"owner_email": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				DiffSuppressFunc: func(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+					return strings.EqualFold(oldValue, newValue)
+				},
+				Description: "Email of the owner of the application, changing it transfers the application to the user with this email who must be a collaborator of the application. " +
+					"The plan fails if the provider user would lose its access to the resources targeting the application: they must reference it with `scalingo_app.<name>.id` or `scalingo_app.<name>.name` rather than a literal value to be checked, " +
+					"and only the ones referencing its name can be checked when the application is created",
+			},
+			"owner_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the owner of the application",
+			},
+			"owner_username": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Username of the owner of the application",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Prevent the application from being destroyed or replaced, the deletion fails until it is disabled by a prior apply",
+			},
 		},
 
 		Importer: &schema.ResourceImporter{
@@ -97,7 +169,7 @@ func resourceScalingoApp() *schema.Resource {
 }
 
 func resourceAppCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appName, _ := d.Get("name").(string)
 
@@ -120,6 +192,8 @@ func resourceAppCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diag.Errorf("create app: %v", err)
 	}
 
+	ownerEmail, _ := d.Get("owner_email").(string)
+
 	d.SetId(app.ID)
 	err = SetAll(d, map[string]interface{}{
 		"base_url":     app.BaseURL,
@@ -136,12 +210,15 @@ func resourceAppCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	if d.Get("environment") != nil {
 		environment, _ := d.Get("environment").(map[string]interface{})
+		sensitiveEnvironment, _ := d.Get("sensitive_environment").(map[string]interface{})
 		var variables scalingo.Variables
-		for name, value := range environment {
-			variables = append(variables, &scalingo.Variable{
-				Name:  name,
-				Value: value.(string),
-			})
+		for _, env := range []map[string]interface{}{environment, sensitiveEnvironment} {
+			for name, value := range env {
+				variables = append(variables, &scalingo.Variable{
+					Name:  name,
+					Value: value.(string),
+				})
+			}
 		}
 
 		_, err := client.VariableMultipleSet(ctx, d.Id(), variables)
@@ -180,16 +257,33 @@ func resourceAppCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 		}
 	}
 
-	return nil
+	// The application is transferred last, the provider user may not be
+	// allowed to configure it anymore.
+	if ownerEmail != "" && !strings.EqualFold(ownerEmail, app.Owner.Email) {
+		app, err = client.AppsTransfer(ctx, app.ID, ownerEmail)
+		if err != nil {
+			return diag.Errorf("transfer application: %v", err)
+		}
+	}
+	err = setAppOwner(d, app.Owner)
+	if err != nil {
+		return diag.Errorf("store application owner: %v", err)
+	}
+
+	return deprecatedStackWarning(ctx, client, app.StackID)
 }
 
 func resourceAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	id := d.Id()
 
 	app, err := client.AppsShow(ctx, id)
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_app")
+			return nil
+		}
 		return diag.Errorf("fetch application: %v", err)
 	}
 
@@ -209,6 +303,10 @@ func resourceAppRead(ctx context.Context, d *schema.ResourceData, meta interface
 	if err != nil {
 		return diag.Errorf("store application information: %v", err)
 	}
+	err = setAppOwner(d, app.Owner)
+	if err != nil {
+		return diag.Errorf("store application owner: %v", err)
+	}
 
 	variables, err := client.VariablesList(ctx, d.Id())
 	if err != nil {
@@ -216,30 +314,45 @@ func resourceAppRead(ctx context.Context, d *schema.ResourceData, meta interface
 	}
 
 	currentEnvironment, _ := d.Get("environment").(map[string]interface{})
+	currentSensitiveEnvironment, _ := d.Get("sensitive_environment").(map[string]interface{})
 
 	environment := make(map[string]interface{})
+	sensitiveEnvironment := make(map[string]interface{})
 	allEnvironment := make(map[string]interface{})
 
 	for _, variable := range variables {
 		if _, ok := currentEnvironment[variable.Name]; ok {
 			environment[variable.Name] = variable.Value
 		}
+		if _, ok := currentSensitiveEnvironment[variable.Name]; ok {
+			sensitiveEnvironment[variable.Name] = variable.Value
+		}
 		allEnvironment[variable.Name] = variable.Value
 	}
 
 	err = SetAll(d, map[string]interface{}{
-		"all_environment": allEnvironment,
-		"environment":     environment,
+		"all_environment":       allEnvironment,
+		"environment":           environment,
+		"sensitive_environment": sensitiveEnvironment,
 	})
 	if err != nil {
 		return diag.Errorf("store application environment: %v", err)
 	}
+	client.variables.setAppEnvironment(d.Id(), environmentNames(environment, sensitiveEnvironment))
 
-	return nil
+	return deprecatedStackWarning(ctx, client, app.StackID)
 }
 
 func resourceAppUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	var diags diag.Diagnostics
 
 	if d.HasChange("name") {
 		oldName, newName := d.GetChange("name")
@@ -260,32 +373,14 @@ func resourceAppUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 		}
 	}
 
-	if d.HasChange("environment") {
-		oldVariables, newVariables := d.GetChange("environment")
-		diff := MapDiff(oldVariables.(map[string]interface{}), newVariables.(map[string]interface{}))
-		variables, _ := newVariables.(map[string]interface{})
+	if d.HasChanges("environment", "sensitive_environment") {
+		variablesToSet, variablesToDelete := environmentChanges(d, "environment", "sensitive_environment")
 
-		err := deleteVariablesByName(ctx, client, d.Id(), diff.Deleted)
+		err := deleteVariablesByName(ctx, client, d.Id(), variablesToDelete)
 		if err != nil {
 			return diag.Errorf("delete variables: %v", err)
 		}
 
-		var variablesToSet scalingo.Variables
-
-		for _, name := range diff.Added {
-			variablesToSet = append(variablesToSet, &scalingo.Variable{
-				Name:  name,
-				Value: variables[name].(string),
-			})
-		}
-
-		for _, name := range diff.Modified {
-			variablesToSet = append(variablesToSet, &scalingo.Variable{
-				Name:  name,
-				Value: variables[name].(string),
-			})
-		}
-
 		_, err = client.VariableMultipleSet(ctx, d.Id(), variablesToSet)
 		if err != nil {
 			return diag.Errorf("set variables: %v", err)
@@ -301,9 +396,20 @@ func resourceAppUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 			return diag.Errorf("store application environment: %v", err)
 		}
 
-		err = restartApp(ctx, client, d.Id())
-		if err != nil {
-			return diag.Errorf("restart app: %v", err)
+		// d.Get can't tell a false boolean apart from an unset one, which
+		// restarts the application.
+		//nolint:staticcheck // GetOkExists is deprecated but does it.
+		if restart, set := d.GetOkExists("restart_on_environment_change"); !set || restart.(bool) {
+			var scope []string
+			for _, containerType := range d.Get("restart_container_types").(*schema.Set).List() {
+				name, _ := containerType.(string)
+				scope = append(scope, name)
+			}
+			sort.Strings(scope)
+			diags = append(diags, restartApp(ctx, client, d.Id(), scope, d.Timeout(schema.TimeoutUpdate))...)
+			if diags.HasError() {
+				return diags
+			}
 		}
 	}
 
@@ -332,10 +438,34 @@ func resourceAppUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 
 	if d.HasChange("stack_id") {
-		_, stackID := d.GetChange("stack_id")
+		oldStackID, stackID := d.GetChange("stack_id")
+		// The revision to deploy again is resolved before changing the stack,
+		// so that the stack isn't changed if it can't be deployed.
+		var redeploy *scalingo.DeploymentsCreateParams
+		if enabled, _ := d.Get("redeploy_on_stack_change").(bool); enabled {
+			var err error
+			redeploy, err = redeployParams(ctx, client, d.Id())
+			if err != nil {
+				_ = d.Set("stack_id", oldStackID)
+				return append(diags, diag.Errorf("deploy the application again on its new stack: %v", err)...)
+			}
+		}
+
 		_, err := client.AppsSetStack(ctx, d.Id(), stackID.(string))
 		if err != nil {
-			return diag.Errorf("set application stack: %v", err)
+			_ = d.Set("stack_id", oldStackID)
+			return append(diags, diag.Errorf("set application stack: %v", err)...)
+		}
+		diags = append(diags, deprecatedStackWarning(ctx, client, stackID.(string))...)
+
+		if redeploy != nil {
+			diags = append(diags, redeployApp(ctx, client, d.Id(), redeploy, d.Timeout(schema.TimeoutUpdate))...)
+			if diags.HasError() {
+				// The stack change and the deployment are tried again by the
+				// next apply.
+				_ = d.Set("stack_id", oldStackID)
+				return diags
+			}
 		}
 	}
 
@@ -355,34 +485,225 @@ func resourceAppUpdate(ctx context.Context, d *schema.ResourceData, meta interfa
 		}
 	}
 
-	return nil
+	// The application is transferred last, the provider user may not be
+	// allowed to configure it anymore.
+	if d.HasChange("owner_email") {
+		oldOwnerEmail, newOwnerEmail := d.GetChange("owner_email")
+		app, err := client.AppsTransfer(ctx, d.Id(), newOwnerEmail.(string))
+		if err != nil {
+			// The transfer is tried again by the next apply.
+			_ = d.Set("owner_email", oldOwnerEmail)
+			return append(diags, diag.Errorf("transfer application: %v", err)...)
+		}
+		err = setAppOwner(d, app.Owner)
+		if err != nil {
+			return append(diags, diag.Errorf("store application owner: %v", err)...)
+		}
+	}
+
+	return diags
 }
 
 func resourceAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	id := d.Id()
 	name, _ := d.Get("name").(string)
 
-	err := client.AppsDestroy(ctx, id, name)
+	if deletionProtection, _ := d.Get("deletion_protection").(bool); deletionProtection {
+		return deletionProtectionError("application", name)
+	}
+
+	err = client.AppsDestroy(ctx, id, name)
 	if err != nil {
 		return diag.Errorf("destroy app: %v", err)
 	}
 	return nil
 }
 
-func restartApp(ctx context.Context, client *scalingo.Client, id string) error {
-	// Ignore the restart error, here the error is probably linked to the
-	// application status, which means that the environment will be applied
-	// later.
-	// If the restart occurred, wait synchronously until the end of the restart
-	// to validate that everything went fine
-	location, err := client.AppsRestart(ctx, id, nil)
-	if err == nil && location != "" {
-		err = waitOperation(ctx, client, location)
-		if err != nil {
-			return fmt.Errorf("get wait for the operation to finish: %v", err)
+func resourceAppCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	environment, _ := d.Get("environment").(map[string]interface{})
+	sensitiveEnvironment, _ := d.Get("sensitive_environment").(map[string]interface{})
+	for name := range sensitiveEnvironment {
+		if _, ok := environment[name]; ok {
+			return fmt.Errorf("environment variable %v is defined in both environment and sensitive_environment", name)
+		}
+	}
+	client, _ := meta.(*providerMeta)
+	if d.Id() != "" {
+		client.variables.setAppEnvironment(d.Id(), environmentNames(environment, sensitiveEnvironment))
+	}
+
+	err := checkAppTransfer(ctx, client, d)
+	if err != nil {
+		return err
+	}
+
+	if !shouldValidate(d, "stack_id") {
+		return nil
+	}
+
+	return validateStack(ctx, client, d.Get("stack_id").(string))
+}
+
+// checkAppTransfer records whether the planned transfer of the application
+// would remove the access of the provider user, so that the plan of the
+// resources targeting the application fails.
+//
+// An application being created is recorded by its configured name: its ID
+// isn't known yet.
+func checkAppTransfer(ctx context.Context, client *providerMeta, d *schema.ResourceDiff) error {
+	oldName, newName := d.GetChange("name")
+	name, _ := oldName.(string)
+	if d.Id() == "" {
+		if !d.NewValueKnown("name") {
+			return nil
+		}
+		name, _ = newName.(string)
+	}
+
+	oldOwnerEmail, newOwnerEmail := d.GetChange("owner_email")
+	ownerEmail, _ := newOwnerEmail.(string)
+	if !d.NewValueKnown("owner_email") || ownerEmail == "" || strings.EqualFold(oldOwnerEmail.(string), ownerEmail) {
+		client.transfers.setOrphaning(d.Id(), name, "")
+		return nil
+	}
+
+	keepsAccess, err := keepsAccessAfterTransfer(ctx, client, d.Id(), ownerEmail)
+	if err != nil {
+		return fmt.Errorf("check the access to the application after its transfer: %v", err)
+	}
+	if keepsAccess {
+		ownerEmail = ""
+	}
+	client.transfers.setOrphaning(d.Id(), name, ownerEmail)
+	return nil
+}
+
+func setAppOwner(d *schema.ResourceData, owner scalingo.Owner) error {
+	return SetAll(d, map[string]interface{}{
+		"owner_email":    owner.Email,
+		"owner_id":       owner.ID,
+		"owner_username": owner.Username,
+	})
+}
+
+// restartApp restarts the containers of the given types, all of them if the
+// scope is empty, and waits for the restart to be done.
+//
+// The environment has already been changed when the application is
+// restarted: a restart which can't be done or which fails is reported as a
+// warning, the new environment will be applied by the next restart.
+func restartApp(ctx context.Context, client *providerMeta, id string, scope []string, timeout time.Duration) diag.Diagnostics {
+	var params *scalingo.AppsRestartParams
+	if len(scope) > 0 {
+		params = &scalingo.AppsRestartParams{Scope: scope}
+	}
+
+	location, err := client.AppsRestart(ctx, id, params)
+	if err != nil {
+		return diag.Diagnostics{restartWarning(err)}
+	}
+	if location == "" {
+		return nil
+	}
+
+	err = waitOperation(ctx, client, location, "the application restart", timeout)
+	var failed *operationFailedError
+	if errors.As(err, &failed) {
+		return diag.Diagnostics{restartWarning(err)}
+	}
+	if err != nil {
+		return diag.Errorf("wait for the application restart: %v", err)
+	}
+	return nil
+}
+
+// redeployParams returns the deployment of the revision currently running
+// the application, from the archive of its SCM repository at the git
+// reference of its last successful deployment. It returns nil if the
+// application has never been successfully deployed: it will use its new stack
+// on its first deployment.
+func redeployParams(ctx context.Context, client *providerMeta, id string) (*scalingo.DeploymentsCreateParams, error) {
+	deployments, err := client.DeploymentList(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("list deployments: %v", err)
+	}
+	var last *scalingo.Deployment
+	for _, deployment := range deployments {
+		if deployment.Status == scalingo.StatusSuccess {
+			last = deployment
+			break
 		}
 	}
+	if last == nil {
+		return nil, nil
+	}
+	if last.GitRef == "" {
+		return nil, fmt.Errorf("the last deployment %v has no git reference to deploy again", last.ID)
+	}
+
+	link, err := client.SCMRepoLinkShow(ctx, id)
+	if isNotFoundError(err) {
+		return nil, fmt.Errorf("the application isn't linked to a SCM repository to deploy %v from", last.GitRef)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get SCM repository link: %v", err)
+	}
+
+	sourceURL, err := scmArchiveURL(link, last.GitRef)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSourceReachable(ctx, sourceURL)
+	if err != nil {
+		return nil, err
+	}
+	return &scalingo.DeploymentsCreateParams{GitRef: &last.GitRef, SourceURL: sourceURL}, nil
+}
+
+// scmArchiveURL returns the URL of the archive of the linked SCM repository
+// at the given git reference.
+func scmArchiveURL(link *scalingo.SCMRepoLink, gitRef string) (string, error) {
+	baseURL := strings.TrimSuffix(link.URL, "/")
+	switch link.SCMType {
+	case scalingo.SCMGithubType, scalingo.SCMGithubEnterpriseType:
+		return fmt.Sprintf("%s/%s/%s/archive/%s.tar.gz", baseURL, link.Owner, link.Repo, gitRef), nil
+	case scalingo.SCMGitlabType, scalingo.SCMGitlabSelfHostedType:
+		return fmt.Sprintf("%s/%s/%s/-/archive/%s/%s-%s.tar.gz", baseURL, link.Owner, link.Repo, gitRef, link.Repo, gitRef), nil
+	}
+	return "", fmt.Errorf("archives of %v repositories are not supported", link.SCMType)
+}
+
+// redeployApp deploys the application again after a change of its stack and
+// waits for the deployment to be done.
+func redeployApp(ctx context.Context, client *providerMeta, id string, params *scalingo.DeploymentsCreateParams, timeout time.Duration) diag.Diagnostics {
+	tflog.Info(ctx, "Deploying the application again on its new stack", map[string]interface{}{
+		"app":        id,
+		"git_ref":    *params.GitRef,
+		"source_url": params.SourceURL,
+	})
+	deployment, err := client.DeploymentsCreate(ctx, id, params)
+	if err != nil {
+		return diag.Errorf("deploy the application on its new stack: %v", err)
+	}
+	_, err = waitDeployment(ctx, client, id, deployment, timeout)
+	if err != nil {
+		return diag.Errorf("deploy the application on its new stack: %v", err)
+	}
 	return nil
 }
+
+func restartWarning(err error) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "The application has not been restarted after the change of its environment",
+		Detail:   fmt.Sprintf("The new environment will be applied by the next restart or deployment of the application: %v", err),
+	}
+}
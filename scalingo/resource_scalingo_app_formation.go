@@ -0,0 +1,272 @@
+package scalingo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func resourceScalingoAppFormation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAppFormationCreate,
+		ReadContext:   resourceAppFormationRead,
+		UpdateContext: resourceAppFormationUpdate,
+		DeleteContext: resourceAppFormationDelete,
+		CustomizeDiff: resourceAppFormationCustomizeDiff,
+		Description: "Resource representing the formation of an application: all its container types, scaled at once. " +
+			"A container type must not be managed both by this resource and by a `scalingo_container_type` resource. " +
+			"Destroying the resource leaves the containers running",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(scaleTimeout),
+			Update: schema.DefaultTimeout(scaleTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the targeted application",
+			},
+			"container_type": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Container types of the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the container type",
+						},
+						"amount": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Number of containers to boot for this type",
+						},
+						"size": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Size of the containers (S/M/L/etc.), the current size is kept if unset",
+						},
+					},
+				},
+			},
+			"scale_undeclared_to_zero": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Scale to zero the container types of the application which are not declared in `container_type`",
+			},
+		},
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAppFormationImport,
+		},
+	}
+}
+
+func resourceAppFormationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	err = scaleAppFormation(ctx, client, d, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(appID)
+
+	return nil
+}
+
+func resourceAppFormationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+
+	containers, err := client.AppsContainerTypes(ctx, appID)
+	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_app_formation")
+			return nil
+		}
+		return diag.Errorf("list container types: %v", err)
+	}
+
+	declared := map[string]map[string]interface{}{}
+	for _, containerType := range d.Get("container_type").(*schema.Set).List() {
+		containerType, _ := containerType.(map[string]interface{})
+		declared[containerType["name"].(string)] = containerType
+	}
+	scaleUndeclaredToZero, _ := d.Get("scale_undeclared_to_zero").(bool)
+
+	// A declared container type which doesn't exist anymore is dropped from
+	// the state, so that the plan shows it as added again.
+	formation := make([]interface{}, 0, len(containers))
+	for _, ct := range containers {
+		containerType, ok := declared[ct.Name]
+		if !ok {
+			// The undeclared container types only appear in the state, and
+			// thus in the plan as removed, if they must be scaled to zero.
+			if !scaleUndeclaredToZero || ct.Amount == 0 {
+				continue
+			}
+			containerType = map[string]interface{}{"size": ct.Size}
+		}
+		size, _ := containerType["size"].(string)
+		if size != "" {
+			size = ct.Size
+		}
+		formation = append(formation, map[string]interface{}{
+			"name":   ct.Name,
+			"amount": ct.Amount,
+			"size":   size,
+		})
+	}
+
+	err = d.Set("container_type", formation)
+	if err != nil {
+		return diag.Errorf("store container types: %v", err)
+	}
+
+	return nil
+}
+
+func resourceAppFormationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, d.Get("app").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	err = scaleAppFormation(ctx, client, d, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceAppFormationDelete leaves the containers running, as the deletion
+// of a scalingo_container_type does: destroying or replacing the resource
+// mustn't take the application down.
+func resourceAppFormationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// scaleAppFormation scales all the declared container types in a single
+// request, along with the undeclared ones to zero if required.
+func scaleAppFormation(ctx context.Context, client *providerMeta, d *schema.ResourceData, timeout time.Duration) error {
+	appID, _ := d.Get("app").(string)
+
+	declared := map[string]bool{}
+	containers := []scalingo.ContainerType{}
+	for _, containerType := range d.Get("container_type").(*schema.Set).List() {
+		containerType, _ := containerType.(map[string]interface{})
+		name, _ := containerType["name"].(string)
+		declared[name] = true
+		containers = append(containers, scalingo.ContainerType{
+			Name:   name,
+			Size:   containerType["size"].(string),
+			Amount: containerType["amount"].(int),
+		})
+	}
+
+	if d.Get("scale_undeclared_to_zero").(bool) {
+		current, err := client.AppsContainerTypes(ctx, appID)
+		if err != nil {
+			return fmt.Errorf("list container types: %v", err)
+		}
+		for _, ct := range current {
+			if declared[ct.Name] || ct.Amount == 0 {
+				continue
+			}
+			tflog.Info(ctx, fmt.Sprintf("Scaling the undeclared container type %s of application %s to zero", ct.Name, appID))
+			containers = append(containers, scalingo.ContainerType{Name: ct.Name, Amount: 0})
+		}
+	}
+
+	return scaleContainers(ctx, client, appID, containers, timeout)
+}
+
+func resourceAppFormationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("container_type") || !d.NewValueKnown("container_type") {
+		return nil
+	}
+	client, _ := meta.(*providerMeta)
+
+	declared := map[string]bool{}
+	for _, containerType := range d.Get("container_type").(*schema.Set).List() {
+		containerType, _ := containerType.(map[string]interface{})
+		name, _ := containerType["name"].(string)
+		if declared[name] {
+			return fmt.Errorf("container type %v is declared more than once", name)
+		}
+		declared[name] = true
+
+		if containerType["amount"].(int) < 0 {
+			return fmt.Errorf("the amount of container type %v must not be negative", name)
+		}
+		size, _ := containerType["size"].(string)
+		if size == "" {
+			continue
+		}
+		err := validateContainerSize(ctx, client, size)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resourceAppFormationImport is called when importing a new app_formation
+// resource. The ID is the application ID, all the container types of the
+// application having containers are imported.
+func resourceAppFormationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client, _ := meta.(*providerMeta)
+
+	appID := d.Id()
+	containers, err := client.AppsContainerTypes(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("list container types: %v", err)
+	}
+
+	formation := make([]interface{}, 0, len(containers))
+	for _, ct := range containers {
+		if ct.Amount == 0 {
+			continue
+		}
+		formation = append(formation, map[string]interface{}{
+			"name":   ct.Name,
+			"amount": ct.Amount,
+			"size":   ct.Size,
+		})
+	}
+
+	err = SetAll(d, map[string]interface{}{
+		"app":                      appID,
+		"container_type":           formation,
+		"scale_undeclared_to_zero": false,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("store formation information: %v", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,221 @@
+package scalingo
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceAppFormation_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	app.containers = append(app.containers, scalingo.ContainerType{AppID: app.ID, Name: "clock", Amount: 1, Size: "S"})
+
+	state := mustApplyResource(t, "scalingo_app_formation", meta, nil, map[string]any{
+		"app": app.ID,
+		"container_type": []any{
+			map[string]any{"name": "web", "amount": 2, "size": "L"},
+			map[string]any{"name": "worker", "amount": 3},
+		},
+	})
+	if state.ID != app.ID {
+		t.Errorf("expected ID %s, got %s", app.ID, state.ID)
+	}
+	if count := f.requestCount(http.MethodPost, "/v1/apps/"+app.ID+"/scale"); count != 1 {
+		t.Errorf("expected the container types to be scaled in a single request, got %d requests", count)
+	}
+	expected := map[string]scalingo.ContainerType{
+		"web":    {Amount: 2, Size: "L"},
+		"clock":  {Amount: 1, Size: "S"},
+		"worker": {Amount: 3, Size: "M"},
+	}
+	assertContainerTypes(t, app, expected)
+
+	// The undeclared clock container type is left untouched.
+	state = mustRefreshResource(t, "scalingo_app_formation", meta, state)
+	assertAttr(t, state, "container_type.#", "2")
+
+	state = mustApplyResource(t, "scalingo_app_formation", meta, state, map[string]any{
+		"app": app.ID,
+		"container_type": []any{
+			map[string]any{"name": "web", "amount": 1, "size": "L"},
+			map[string]any{"name": "worker", "amount": 3},
+		},
+	})
+	expected["web"] = scalingo.ContainerType{Amount: 1, Size: "L"}
+	assertContainerTypes(t, app, expected)
+
+	// Destroying the resource doesn't scale the containers down.
+	mustDestroyResource(t, "scalingo_app_formation", meta, state)
+	assertContainerTypes(t, app, expected)
+}
+
+func TestResourceAppFormation_ScaleUndeclaredToZero(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	app.containers = append(app.containers, scalingo.ContainerType{AppID: app.ID, Name: "clock", Amount: 1, Size: "S"})
+
+	config := map[string]any{
+		"app":                      app.ID,
+		"scale_undeclared_to_zero": true,
+		"container_type": []any{
+			map[string]any{"name": "web", "amount": 2},
+		},
+	}
+	state := mustApplyResource(t, "scalingo_app_formation", meta, nil, config)
+	if count := f.requestCount(http.MethodPost, "/v1/apps/"+app.ID+"/scale"); count != 1 {
+		t.Errorf("expected the container types to be scaled in a single request, got %d requests", count)
+	}
+	assertContainerTypes(t, app, map[string]scalingo.ContainerType{
+		"web":   {Amount: 2, Size: "M"},
+		"clock": {Amount: 0, Size: "S"},
+	})
+
+	// A container type scaled up outside of Terraform is a drift.
+	app.containers[1].Amount = 2
+	state = mustRefreshResource(t, "scalingo_app_formation", meta, state)
+	assertAttr(t, state, "container_type.#", "2")
+	diff, diags := planResource(t, "scalingo_app_formation", meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff == nil || diff.Empty() {
+		t.Fatal("expected the undeclared container type to be planned for scaling to zero")
+	}
+
+	mustApplyResource(t, "scalingo_app_formation", meta, state, config)
+	if app.containers[1].Amount != 0 {
+		t.Errorf("expected the undeclared container type to be scaled to zero, got %+v", app.containers[1])
+	}
+}
+
+func TestResourceAppFormation_DisappearedContainerType(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	config := map[string]any{
+		"app": app.ID,
+		"container_type": []any{
+			map[string]any{"name": "web", "amount": 1},
+			map[string]any{"name": "worker", "amount": 1},
+		},
+	}
+	state := mustApplyResource(t, "scalingo_app_formation", meta, nil, config)
+
+	// The worker has been removed from the Procfile.
+	app.containers = app.containers[:1]
+	state = mustRefreshResource(t, "scalingo_app_formation", meta, state)
+	assertAttr(t, state, "container_type.#", "1")
+	diff, diags := planResource(t, "scalingo_app_formation", meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff == nil || diff.Empty() {
+		t.Error("expected the disappeared container type to be planned")
+	}
+}
+
+func TestResourceAppFormation_Validation(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	for name, test := range map[string]struct {
+		containerTypes []any
+		expectedError  string
+	}{
+		"unknown size": {
+			containerTypes: []any{map[string]any{"name": "web", "amount": 1, "size": "XXL"}},
+			expectedError:  `"XXL" is not a valid container size`,
+		},
+		"duplicated container type": {
+			containerTypes: []any{
+				map[string]any{"name": "web", "amount": 1},
+				map[string]any{"name": "web", "amount": 2},
+			},
+			expectedError: "container type web is declared more than once",
+		},
+		"negative amount": {
+			containerTypes: []any{map[string]any{"name": "web", "amount": -1}},
+			expectedError:  "the amount of container type web must not be negative",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, diags := applyResource(t, "scalingo_app_formation", meta, nil, map[string]any{
+				"app": app.ID, "container_type": test.containerTypes,
+			})
+			assertDiagContains(t, diags, test.expectedError)
+		})
+	}
+	if count := f.requestCount(http.MethodPost, "/v1/apps/"+app.ID+"/scale"); count != 0 {
+		t.Errorf("expected no scale request, got %d", count)
+	}
+}
+
+func TestResourceAppFormation_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	app.containers = append(app.containers,
+		scalingo.ContainerType{AppID: app.ID, Name: "worker", Amount: 2, Size: "L"},
+		scalingo.ContainerType{AppID: app.ID, Name: "clock", Amount: 0, Size: "S"},
+	)
+
+	state := mustImportResource(t, "scalingo_app_formation", meta, app.ID)
+	assertAttr(t, state, "app", app.ID)
+	assertAttr(t, state, "scale_undeclared_to_zero", "false")
+	assertAttr(t, state, "container_type.#", "2")
+	diff, diags := planResource(t, "scalingo_app_formation", meta, state, map[string]any{
+		"app": app.ID,
+		"container_type": []any{
+			map[string]any{"name": "web", "amount": 1, "size": "M"},
+			map[string]any{"name": "worker", "amount": 2, "size": "L"},
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected an empty plan after the import, got %v", diff)
+	}
+}
+
+func TestResourceAppFormation_WaitsForScaleOperation(t *testing.T) {
+	t.Run("done", func(t *testing.T) {
+		f, meta := newTestProvider(t)
+		f.pendingPolls = 2
+		app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+		mustApplyResource(t, "scalingo_app_formation", meta, nil, map[string]any{
+			"app": app.ID, "container_type": []any{map[string]any{"name": "web", "amount": 3}},
+		})
+		op := app.operations[0]
+		if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID+"/operations/"+op.ID); count != 3 {
+			t.Errorf("expected the scale operation to be polled until done, got %d requests", count)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		f, meta := newTestProvider(t)
+		f.operationError = "not enough capacity"
+		app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+		_, diags := applyResource(t, "scalingo_app_formation", meta, nil, map[string]any{
+			"app": app.ID, "container_type": []any{map[string]any{"name": "web", "amount": 3}},
+		})
+		assertDiagContains(t, diags, "the scaling of the web containers failed: not enough capacity")
+	})
+}
+
+// assertContainerTypes checks the amount and size of the container types of
+// the application.
+func assertContainerTypes(t *testing.T, app *fakeApp, expected map[string]scalingo.ContainerType) {
+	t.Helper()
+	if len(app.containers) != len(expected) {
+		t.Fatalf("expected %d container types, got %+v", len(expected), app.containers)
+	}
+	for _, ct := range app.containers {
+		if ct.Amount != expected[ct.Name].Amount || ct.Size != expected[ct.Name].Size {
+			t.Errorf("expected container type %s to be %+v, got %+v", ct.Name, expected[ct.Name], ct)
+		}
+	}
+}
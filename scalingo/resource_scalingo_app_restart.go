@@ -0,0 +1,103 @@
+package scalingo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func resourceScalingoAppRestart() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAppRestartCreate,
+		ReadContext:   resourceAppRestartRead,
+		DeleteContext: resourceAppRestartDelete,
+		Description: "Resource restarting the containers of an application, e.g. after the rotation of a credential of an addon. " +
+			"Changing any argument, e.g. `triggers`, restarts the containers again. " +
+			"Destroying the resource only removes it from the state",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(appOperationTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the targeted application",
+			},
+			"scope": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Container types to restart (web, worker, etc.), all of them if unset",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary key-value map whose changes restart the containers again, e.g. the ID of a rotated credential",
+			},
+		},
+	}
+}
+
+func resourceAppRestartCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+
+	var scope []string
+	for _, containerType := range d.Get("scope").([]interface{}) {
+		name, _ := containerType.(string)
+		scope = append(scope, name)
+	}
+	var params *scalingo.AppsRestartParams
+	if len(scope) > 0 {
+		params = &scalingo.AppsRestartParams{Scope: scope}
+	}
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	location, err := client.AppsRestart(ctx, appID, params)
+	if err != nil {
+		return diag.Errorf("restart application: %v", err)
+	}
+	if location != "" {
+		err = waitOperation(ctx, client, location, "the application restart", d.Timeout(schema.TimeoutCreate))
+		if err != nil {
+			return diag.Errorf("wait for the application restart: %v", err)
+		}
+	}
+
+	d.SetId(id.UniqueId())
+	if len(scope) > 0 {
+		tflog.Info(ctx, fmt.Sprintf("Restarted the %s containers of application %s", strings.Join(scope, ", "), appID))
+	} else {
+		tflog.Info(ctx, fmt.Sprintf("Restarted the containers of application %s", appID))
+	}
+
+	return nil
+}
+
+// resourceAppRestartRead doesn't refresh anything: a restart is an event
+// which can't be read back.
+func resourceAppRestartRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceAppRestartDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
@@ -0,0 +1,66 @@
+package scalingo
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceAppRestart_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.pendingPolls = 2
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	config := map[string]any{
+		"app":      app.ID,
+		"triggers": map[string]any{"certificate": "cert-1"},
+	}
+	state := mustApplyResource(t, "scalingo_app_restart", meta, nil, config)
+	if len(app.restarts) != 1 || len(app.restarts[0].Scope) != 0 {
+		t.Fatalf("expected all the containers to be restarted once, got %+v", app.restarts)
+	}
+	op := app.operations[0]
+	if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID+"/operations/"+op.ID); count != 3 {
+		t.Errorf("expected the restart operation to be polled until done, got %d requests", count)
+	}
+
+	state = mustRefreshResource(t, "scalingo_app_restart", meta, state)
+	diff, diags := planResource(t, "scalingo_app_restart", meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected an empty plan, got %v", diff)
+	}
+
+	// Changing the triggers restarts the containers again.
+	state = mustApplyResource(t, "scalingo_app_restart", meta, state, map[string]any{
+		"app":      app.ID,
+		"scope":    []any{"web", "worker"},
+		"triggers": map[string]any{"certificate": "cert-2"},
+	})
+	if len(app.restarts) != 2 || !slices.Equal(app.restarts[1].Scope, []string{"web", "worker"}) {
+		t.Fatalf("expected the web and worker containers to be restarted, got %+v", app.restarts)
+	}
+
+	mustDestroyResource(t, "scalingo_app_restart", meta, state)
+	if len(app.restarts) != 2 {
+		t.Errorf("expected the destruction not to restart the containers, got %d restarts", len(app.restarts))
+	}
+}
+
+func TestResourceAppRestart_FailedRestart(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.operationError = "the web containers failed to boot"
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state, diags := applyResource(t, "scalingo_app_restart", meta, nil, map[string]any{
+		"app": app.ID,
+	})
+	assertDiagContains(t, diags, "wait for the application restart: the application restart failed: the web containers failed to boot")
+	if state != nil && state.ID != "" {
+		t.Errorf("expected the failed restart not to be stored in the state, got %s", state.ID)
+	}
+}
@@ -0,0 +1,227 @@
+package scalingo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func resourceScalingoAppSource() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAppSourceCreate,
+		ReadContext:   resourceAppSourceRead,
+		UpdateContext: resourceAppSourceUpdate,
+		DeleteContext: resourceAppSourceDelete,
+		CustomizeDiff: resourceAppSourceCustomizeDiff,
+		Description: "Resource deploying the source code of a local directory to an application. " +
+			"The directory is deployed again when its content changes. " +
+			"Destroying the resource only removes it from the state: a deployment can't be undone",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(deploymentTimeout),
+			Update: schema.DefaultTimeout(deploymentTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the targeted application",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the local directory to deploy",
+			},
+			"excludes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Patterns of the files and directories not to deploy, with the syntax of a .slugignore file (e.g. `.git`, `*.log`, `/docs`, `tmp/`)",
+			},
+			"git_ref": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Git reference (commit SHA, branch or tag) of the source code, displayed in the deployments history",
+			},
+			"source_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 hash of the deployed files, a change of the files triggers a new deployment",
+			},
+			"deployment_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the last deployment of the source code",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the last deployment (success, build-error, crashed-error, etc.)",
+			},
+		},
+	}
+}
+
+func resourceAppSourceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	err := deployAppSource(ctx, client, d, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAppSourceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+	deploymentID, _ := d.Get("deployment_id").(string)
+
+	deployment, err := client.Deployment(ctx, appID, deploymentID)
+	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_app_source")
+			return nil
+		}
+		return diag.Errorf("get deployment: %v", err)
+	}
+
+	err = d.Set("status", string(deployment.Status))
+	if err != nil {
+		return diag.Errorf("store deployment status: %v", err)
+	}
+
+	return nil
+}
+
+func resourceAppSourceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	if !d.HasChanges("source_hash", "git_ref") {
+		return nil
+	}
+
+	// The previous state is kept if the deployment fails, so that it is
+	// retried by the next apply.
+	d.Partial(true)
+	err := deployAppSource(ctx, client, d, d.Timeout(schema.TimeoutUpdate))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Partial(false)
+
+	return nil
+}
+
+func resourceAppSourceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+// resourceAppSourceCustomizeDiff computes the hash of the local directory so
+// that the plan shows when its content changed.
+func resourceAppSourceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("path") || !d.NewValueKnown("excludes") {
+		return d.SetNewComputed("source_hash")
+	}
+
+	hash, err := packSource(d.Get("path").(string), appSourceExcludes(d.Get("excludes")), io.Discard)
+	if err != nil {
+		return fmt.Errorf("compute the hash of the source code: %v", err)
+	}
+	if hash == d.Get("source_hash").(string) {
+		return nil
+	}
+
+	err = d.SetNew("source_hash", hash)
+	if err != nil {
+		return err
+	}
+	for _, key := range []string{"deployment_id", "status"} {
+		err = d.SetNewComputed(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deployAppSource packs and uploads the directory, then deploys it and waits
+// for the deployment to finish.
+func deployAppSource(ctx context.Context, client *providerMeta, d *schema.ResourceData, timeout time.Duration) error {
+	appID, _ := d.Get("app").(string)
+	dir, _ := d.Get("path").(string)
+
+	var archive bytes.Buffer
+	hash, err := packSource(dir, appSourceExcludes(d.Get("excludes")), &archive)
+	if err != nil {
+		return err
+	}
+
+	source, err := client.SourcesCreate(ctx)
+	if err != nil {
+		return fmt.Errorf("create source: %v", err)
+	}
+	err = uploadSource(ctx, source.UploadURL, archive.Bytes())
+	if err != nil {
+		return err
+	}
+
+	params := &scalingo.DeploymentsCreateParams{
+		SourceURL: source.DownloadURL,
+	}
+	if gitRef, _ := d.Get("git_ref").(string); gitRef != "" {
+		params.GitRef = &gitRef
+	}
+	// The lock is only held while the deployment is created: the build
+	// doesn't prevent the other operations on the application.
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return err
+	}
+	deployment, err := client.DeploymentsCreate(ctx, appID, params)
+	unlock()
+	if err != nil {
+		return fmt.Errorf("create deployment: %v", err)
+	}
+	// The ID is set before waiting so that a failed first deployment is
+	// tainted and deployed again by the next apply.
+	d.SetId(appID)
+
+	deployment, err = waitDeployment(ctx, client, appID, deployment, timeout)
+	setErr := SetAll(d, map[string]interface{}{
+		"deployment_id": deployment.ID,
+		"status":        string(deployment.Status),
+	})
+	if setErr != nil {
+		return fmt.Errorf("store deployment information: %v", setErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	err = d.Set("source_hash", hash)
+	if err != nil {
+		return fmt.Errorf("store source hash: %v", err)
+	}
+	return nil
+}
+
+func appSourceExcludes(value interface{}) []string {
+	list, _ := value.([]interface{})
+	excludes := make([]string, 0, len(list))
+	for _, exclude := range list {
+		pattern, _ := exclude.(string)
+		excludes = append(excludes, pattern)
+	}
+	return excludes
+}
@@ -0,0 +1,112 @@
+package scalingo
+
+import (
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceAppSource_Basic(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	dir := t.TempDir()
+	writeSourceFiles(t, dir, map[string]string{"server.js": "v1", "debug.log": "log"})
+
+	config := map[string]any{
+		"app":      app.ID,
+		"path":     dir,
+		"excludes": []any{"*.log"},
+		"git_ref":  "main",
+	}
+	state := mustApplyResource(t, "scalingo_app_source", meta, nil, config)
+	if state.ID != app.ID {
+		t.Errorf("expected the ID to be the app ID, got %v", state.ID)
+	}
+	if len(app.deployments) != 1 || len(f.sources) != 1 {
+		t.Fatalf("expected 1 uploaded source and 1 deployment, got %d and %d", len(f.sources), len(app.deployments))
+	}
+	assertAttr(t, state, "deployment_id", app.deployments[0].ID)
+	assertAttr(t, state, "status", "success")
+	if app.deployments[0].GitRef != "main" {
+		t.Errorf("expected the git ref to be sent, got %q", app.deployments[0].GitRef)
+	}
+	for _, archive := range f.sources {
+		files := archiveFiles(t, archive)
+		if len(files) != 1 || files["source/server.js"] != "v1" {
+			t.Errorf("unexpected archive content %v", files)
+		}
+	}
+	hash := state.Attributes["source_hash"]
+	if hash == "" {
+		t.Error("expected the source hash to be stored")
+	}
+
+	state = mustRefreshResource(t, "scalingo_app_source", meta, state)
+
+	// Nothing to deploy when the files are unchanged, even if excluded files
+	// are.
+	writeSourceFiles(t, dir, map[string]string{"debug.log": "more log"})
+	diff, diags := planResource(t, "scalingo_app_source", meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected an empty plan, got %v", diff)
+	}
+
+	writeSourceFiles(t, dir, map[string]string{"server.js": "v2"})
+	diff, diags = planResource(t, "scalingo_app_source", meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff == nil || diff.Attributes["source_hash"] == nil || diff.RequiresNew() {
+		t.Fatalf("expected the source hash to be updated in place, got %v", diff)
+	}
+
+	state = mustApplyResource(t, "scalingo_app_source", meta, state, config)
+	if len(app.deployments) != 2 {
+		t.Fatalf("expected a new deployment, got %d deployments", len(app.deployments))
+	}
+	assertAttr(t, state, "deployment_id", app.deployments[1].ID)
+	if state.Attributes["source_hash"] == hash {
+		t.Error("expected the source hash to be updated")
+	}
+
+	mustDestroyResource(t, "scalingo_app_source", meta, state)
+}
+
+func TestResourceAppSource_FailedRedeploy(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	dir := t.TempDir()
+	writeSourceFiles(t, dir, map[string]string{"server.js": "v1"})
+
+	config := map[string]any{"app": app.ID, "path": dir}
+	state := mustApplyResource(t, "scalingo_app_source", meta, nil, config)
+	hash := state.Attributes["source_hash"]
+
+	f.deploymentStatus = scalingo.StatusBuildError
+	writeSourceFiles(t, dir, map[string]string{"server.js": "v2"})
+	newState, diags := applyResource(t, "scalingo_app_source", meta, state, config)
+	assertDiagContains(t, diags, "failed with status build-error")
+	if newState.Attributes["source_hash"] != hash {
+		t.Error("expected the previous source hash to be kept so that the deployment is retried")
+	}
+
+	f.deploymentStatus = ""
+	mustApplyResource(t, "scalingo_app_source", meta, newState, config)
+	if len(app.deployments) != 3 {
+		t.Errorf("expected the deployment to be retried, got %d deployments", len(app.deployments))
+	}
+}
+
+func TestResourceAppSource_MissingDirectory(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	_, diags := planResource(t, "scalingo_app_source", meta, nil, map[string]any{
+		"app":  app.ID,
+		"path": t.TempDir() + "/missing",
+	})
+	assertDiagContains(t, diags, "compute the hash of the source code")
+}
@@ -0,0 +1,555 @@
+package scalingo
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceApp_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{
+		"name": "my-app",
+		"environment": map[string]any{
+			"FOO": "bar",
+		},
+		"force_https": true,
+	})
+
+	app := f.findApp("my-app")
+	if app == nil {
+		t.Fatal("app has not been created")
+	}
+	if state.ID != app.ID {
+		t.Errorf("expected ID %s, got %s", app.ID, state.ID)
+	}
+	if !app.ForceHTTPS {
+		t.Error("expected force HTTPS to be enabled")
+	}
+	assertAttr(t, state, "stack_id", "st-scalingo-22")
+	assertAttr(t, state, "git_url", app.GitURL)
+	assertAttr(t, state, "all_environment.FOO", "bar")
+
+	state = mustRefreshResource(t, "scalingo_app", meta, state)
+	assertAttr(t, state, "name", "my-app")
+	assertAttr(t, state, "environment.FOO", "bar")
+	assertAttr(t, state, "force_https", "true")
+
+	state = mustApplyResource(t, "scalingo_app", meta, state, map[string]any{
+		"name": "my-renamed-app",
+		"environment": map[string]any{
+			"FOO": "baz",
+			"BAR": "qux",
+		},
+		"sticky_session": true,
+		"stack_id":       "st-scalingo-24",
+	})
+	assertAttr(t, state, "name", "my-renamed-app")
+	assertAttr(t, state, "all_environment.FOO", "baz")
+	assertAttr(t, state, "all_environment.BAR", "qux")
+	if app.Name != "my-renamed-app" || !app.StickySession || app.StackID != "st-scalingo-24" {
+		t.Errorf("app has not been updated: %+v", app.App)
+	}
+	if app.ForceHTTPS {
+		t.Error("expected force HTTPS to be disabled")
+	}
+	if len(app.restarts) != 1 {
+		t.Errorf("expected the app to be restarted once after the environment change, got %d", len(app.restarts))
+	}
+
+	mustDestroyResource(t, "scalingo_app", meta, state)
+	if f.findApp(app.ID) != nil {
+		t.Error("app has not been destroyed")
+	}
+}
+
+func TestResourceApp_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+
+	imported := mustImportResource(t, "scalingo_app", meta, state.ID)
+	assertAttr(t, imported, "name", "my-app")
+	assertAttr(t, imported, "stack_id", f.findApp("my-app").StackID)
+
+	// The attributes only known by the provider are left unset.
+	diff, diags := planResource(t, "scalingo_app", meta, imported, map[string]any{"name": "my-app"})
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no change after the import, got %v", diff)
+	}
+}
+
+func TestResourceApp_CreateError(t *testing.T) {
+	_, meta := newTestProvider(t)
+	mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+
+	_, diags := applyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+	assertDiagContains(t, diags, "create app")
+}
+
+func TestResourceApp_ProjectChange(t *testing.T) {
+	f, meta := newTestProvider(t)
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+
+	state = mustApplyResource(t, "scalingo_app", meta, state, map[string]any{
+		"name":       "my-app",
+		"project_id": "pr-other",
+	})
+	assertAttr(t, state, "project_id", "pr-other")
+	if count := f.requestCount(http.MethodPut, "/v1/apps/"+state.ID); count != 1 {
+		t.Errorf("expected a single update request, got %d", count)
+	}
+}
+
+func TestResourceApp_RestartTimeout(t *testing.T) {
+	f, meta := newTestProvider(t)
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+	f.pendingPolls = 1000
+
+	_, diags := applyResource(t, "scalingo_app", meta, state, map[string]any{
+		"name":        "my-app",
+		"environment": map[string]any{"FOO": "bar"},
+		"timeouts":    map[string]any{"update": "50ms"},
+	})
+	assertDiagContains(t, diags, "waiting for the application restart (last status: running)")
+}
+
+func TestResourceApp_SensitiveEnvironment(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	r := testResource(t, "scalingo_app")
+	if !r.Schema["sensitive_environment"].Sensitive || !r.Schema["all_environment"].Sensitive {
+		t.Error("expected sensitive_environment and all_environment to be sensitive")
+	}
+
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{
+		"name":                  "my-app",
+		"environment":           map[string]any{"FOO": "bar"},
+		"sensitive_environment": map[string]any{"API_KEY": "secret"},
+	})
+	app := f.findApp("my-app")
+	if len(app.variables) != 2 {
+		t.Fatalf("expected 2 variables, got %d", len(app.variables))
+	}
+	assertAttr(t, state, "all_environment.API_KEY", "secret")
+
+	state = mustRefreshResource(t, "scalingo_app", meta, state)
+	assertAttr(t, state, "environment.FOO", "bar")
+	assertAttr(t, state, "sensitive_environment.API_KEY", "secret")
+	if _, ok := state.Attributes["environment.API_KEY"]; ok {
+		t.Error("expected the sensitive variable not to be tracked in environment")
+	}
+
+	// FOO is moved to the sensitive environment and API_KEY is removed.
+	state = mustApplyResource(t, "scalingo_app", meta, state, map[string]any{
+		"name":                  "my-app",
+		"sensitive_environment": map[string]any{"FOO": "bar", "DB_PASSWORD": "password"},
+	})
+	names := map[string]string{}
+	for _, variable := range app.variables {
+		names[variable.Name] = variable.Value
+	}
+	if len(names) != 2 || names["FOO"] != "bar" || names["DB_PASSWORD"] != "password" {
+		t.Errorf("unexpected variables %v", names)
+	}
+	deletions := 0
+	unlock := f.lock()
+	for _, request := range f.requests {
+		if strings.HasPrefix(request, http.MethodDelete+" /v1/apps/"+app.ID+"/variables/") {
+			deletions++
+		}
+	}
+	unlock()
+	if deletions != 1 {
+		t.Errorf("expected only the removed variable to be deleted, got %d deletions", deletions)
+	}
+	state = mustRefreshResource(t, "scalingo_app", meta, state)
+	assertAttr(t, state, "sensitive_environment.FOO", "bar")
+	if _, ok := state.Attributes["sensitive_environment.API_KEY"]; ok {
+		t.Error("expected the removed variable not to be tracked anymore")
+	}
+}
+
+func TestResourceApp_EnvironmentConflict(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	_, diags := planResource(t, "scalingo_app", meta, nil, map[string]any{
+		"name":                  "my-app",
+		"environment":           map[string]any{"API_KEY": "public"},
+		"sensitive_environment": map[string]any{"API_KEY": "secret"},
+	})
+	assertDiagContains(t, diags, "environment variable API_KEY is defined in both environment and sensitive_environment")
+}
+
+func TestResourceApp_RestartOnEnvironmentChange(t *testing.T) {
+	tests := map[string]struct {
+		config           map[string]any
+		expectedRestarts int
+		expectedScope    []string
+	}{
+		"default":         {expectedRestarts: 1},
+		"enabled":         {config: map[string]any{"restart_on_environment_change": true}, expectedRestarts: 1},
+		"disabled":        {config: map[string]any{"restart_on_environment_change": false}, expectedRestarts: 0},
+		"container types": {config: map[string]any{"restart_container_types": []any{"worker", "web"}}, expectedRestarts: 1, expectedScope: []string{"web", "worker"}},
+		"disabled with container types": {
+			config:           map[string]any{"restart_on_environment_change": false, "restart_container_types": []any{"web"}},
+			expectedRestarts: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, meta := newTestProvider(t)
+			config := map[string]any{"name": "my-app", "environment": map[string]any{"FOO": "bar"}}
+			for key, value := range test.config {
+				config[key] = value
+			}
+			state := mustApplyResource(t, "scalingo_app", meta, nil, config)
+			app := f.findApp("my-app")
+
+			config["environment"] = map[string]any{"FOO": "baz"}
+			mustApplyResource(t, "scalingo_app", meta, state, config)
+			if len(app.restarts) != test.expectedRestarts {
+				t.Fatalf("expected %d restarts, got %d", test.expectedRestarts, len(app.restarts))
+			}
+			if test.expectedRestarts > 0 && !slices.Equal(app.restarts[0].Scope, test.expectedScope) {
+				t.Errorf("expected the scope %v, got %v", test.expectedScope, app.restarts[0].Scope)
+			}
+		})
+	}
+}
+
+func TestResourceApp_InvalidRestartContainerTypes(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	_, diags := planResource(t, "scalingo_app", meta, nil, map[string]any{
+		"name":                    "my-app",
+		"restart_container_types": []any{"web", ""},
+	})
+	assertDiagContains(t, diags, `invalid container type name ""`)
+}
+
+func TestResourceApp_FailedRestartIsAWarning(t *testing.T) {
+	f, meta := newTestProvider(t)
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+	app := f.findApp("my-app")
+	f.operationError = "container web-1 failed to boot"
+
+	_, diags := applyResource(t, "scalingo_app", meta, state, map[string]any{
+		"name":        "my-app",
+		"environment": map[string]any{"FOO": "bar"},
+	})
+	if diags.HasError() {
+		t.Fatalf("expected no error, got %v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "container web-1 failed to boot") {
+		t.Errorf("expected a warning about the failed restart, got %v", diags)
+	}
+	if _, ok := app.variables.Contains("FOO"); !ok {
+		t.Error("expected the environment to be changed")
+	}
+}
+
+func TestResourceApp_Transfer(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+	assertAttr(t, state, "owner_email", fakeOwner.Email)
+	assertAttr(t, state, "owner_id", fakeOwner.ID)
+	assertAttr(t, state, "owner_username", fakeOwner.Username)
+	app := f.findApp("my-app")
+
+	// The case of the email doesn't matter.
+	diff, diags := planResource(t, "scalingo_app", meta, state, map[string]any{
+		"name":        "my-app",
+		"owner_email": strings.ToUpper(fakeOwner.Email),
+	})
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected an empty plan, got %v", diff)
+	}
+
+	state = mustApplyResource(t, "scalingo_app", meta, state, map[string]any{
+		"name":        "my-app",
+		"owner_email": "customer@fake.test",
+	})
+	if f.requestCount(http.MethodPatch, "/v1/apps/"+app.ID) != 1 {
+		t.Error("expected the application to be transferred")
+	}
+	assertAttr(t, state, "owner_email", "customer@fake.test")
+	assertAttr(t, state, "owner_username", "customer")
+	assertAttr(t, state, "owner_id", app.Owner.ID)
+}
+
+func TestResourceApp_TransferOnCreation(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{
+		"name":        "my-app",
+		"owner_email": "customer@fake.test",
+	})
+	app := f.findApp("my-app")
+	if app.Owner.Email != "customer@fake.test" {
+		t.Errorf("expected the application to be transferred, owned by %v", app.Owner.Email)
+	}
+	assertAttr(t, state, "owner_email", "customer@fake.test")
+}
+
+func TestResourceApp_TransferOrphaningResources(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	appState := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+	app := f.findApp("my-app")
+	domainConfig := map[string]any{"app": app.ID, "common_name": "example.com"}
+	domainState := mustApplyResource(t, "scalingo_domain", meta, nil, domainConfig)
+
+	transferConfig := map[string]any{"name": "my-app", "owner_email": "customer@fake.test"}
+	_, diags := planResource(t, "scalingo_app", meta, appState, transferConfig)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	_, diags = planResource(t, "scalingo_domain", meta, domainState, domainConfig)
+	assertDiagContains(t, diags, "the transfer of application "+app.ID+" to customer@fake.test would orphan this resource")
+
+	// The provider user keeps its access as a collaborator.
+	app.collaborators = append(app.collaborators, &scalingo.Collaborator{
+		ID:     "collab-self",
+		AppID:  app.ID,
+		Email:  fakeOwner.Email,
+		UserID: fakeOwner.ID,
+		Status: scalingo.CollaboratorStatusAccepted,
+	})
+	_, diags = planResource(t, "scalingo_app", meta, appState, transferConfig)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	_, diags = planResource(t, "scalingo_domain", meta, domainState, domainConfig)
+	if diags.HasError() {
+		t.Errorf("expected the domain to be planned, got %v", diags)
+	}
+}
+
+func TestResourceApp_TransferOnCreationOrphaningResources(t *testing.T) {
+	_, meta := newTestProvider(t)
+	domainConfig := map[string]any{"app": "my-app", "common_name": "example.com"}
+
+	_, diags := planResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app", "owner_email": "customer@fake.test"})
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	_, diags = planResource(t, "scalingo_domain", meta, nil, domainConfig)
+	assertDiagContains(t, diags, "the transfer of application my-app to customer@fake.test would orphan this resource")
+
+	// The provider user keeps owning the application.
+	_, diags = planResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app", "owner_email": fakeOwner.Email})
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	_, diags = planResource(t, "scalingo_domain", meta, nil, domainConfig)
+	if diags.HasError() {
+		t.Errorf("expected the domain to be planned, got %v", diags)
+	}
+}
+
+// The resources are planned by Terraform itself, which plans the application
+// before the resources referencing it.
+func TestResourceApp_TransferOrphaningResourcesWithTerraform(t *testing.T) {
+	f := newFakeAPI(t)
+	config := func(ownerEmail, appAttribute string) string {
+		owner := ""
+		if ownerEmail != "" {
+			owner = fmt.Sprintf("\n  owner_email = %q", ownerEmail)
+		}
+		return testProviderConfig(f) + fmt.Sprintf(`
+resource "scalingo_app" "app" {
+  name = "my-app"%s
+}
+
+resource "scalingo_domain" "domain" {
+  app         = scalingo_app.app.%s
+  common_name = "example.com"
+}
+`, owner, appAttribute)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testTerraformPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The ID of an application being created isn't known yet.
+				Config:      config("customer@fake.test", "name"),
+				ExpectError: regexp.MustCompile(`transfer\s+of\s+application\s+my-app\s+to\s+customer@fake.test\s+would\s+orphan`),
+			},
+			{
+				Config: config("", "id"),
+				Check:  resource.TestCheckResourceAttr("scalingo_domain.domain", "common_name", "example.com"),
+			},
+			{
+				Config:      config("customer@fake.test", "id"),
+				ExpectError: regexp.MustCompile(`to\s+customer@fake.test\s+would\s+orphan\s+this\s+resource`),
+			},
+			{
+				// The provider user keeps owning the application.
+				Config: config(fakeOwner.Email, "id"),
+				Check:  resource.TestCheckResourceAttr("scalingo_app.app", "owner_email", fakeOwner.Email),
+			},
+		},
+	})
+}
+
+func TestResourceApp_DeletionProtection(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{
+		"name":                "my-app",
+		"deletion_protection": true,
+	})
+
+	diags := destroyResource(t, "scalingo_app", meta, state)
+	assertDiagContains(t, diags, "application my-app is protected against deletion")
+	if f.findApp("my-app") == nil {
+		t.Fatal("expected the application not to be destroyed")
+	}
+
+	state = mustApplyResource(t, "scalingo_app", meta, state, map[string]any{
+		"name":                "my-app",
+		"deletion_protection": false,
+	})
+	mustDestroyResource(t, "scalingo_app", meta, state)
+	if f.findApp("my-app") != nil {
+		t.Error("expected the application to be destroyed")
+	}
+}
+
+func TestResourceApp_RedeployOnStackChange(t *testing.T) {
+	// The URLs of the links are completed by the address of the fake API.
+	githubLink := &scalingo.SCMRepoLink{ID: "link-1", URL: "/scm", Owner: "my-org", Repo: "my-app", Branch: "main", SCMType: scalingo.SCMGithubType}
+	gitlabLink := &scalingo.SCMRepoLink{ID: "link-1", URL: "/scm", Owner: "my-group", Repo: "my-app", Branch: "main", SCMType: scalingo.SCMGitlabSelfHostedType}
+	privateLink := &scalingo.SCMRepoLink{ID: "link-1", URL: "/scm", Owner: "my-org", Repo: "private-app", Branch: "main", SCMType: scalingo.SCMGithubType}
+	tests := map[string]struct {
+		redeploy          bool
+		link              *scalingo.SCMRepoLink
+		deployed          bool
+		deployedGitRef    string
+		deployedStatus    scalingo.DeploymentStatus
+		deploymentStatus  scalingo.DeploymentStatus
+		expectedError     string
+		expectedSourceURL string
+		expectedStack     string
+		// expectedStateStack is the stack stored in the state, the one of the
+		// application by default.
+		expectedStateStack string
+	}{
+		"github":             {redeploy: true, link: githubLink, deployed: true, deployedGitRef: "0123abc", expectedSourceURL: "/scm/my-org/my-app/archive/0123abc.tar.gz"},
+		"gitlab":             {redeploy: true, link: gitlabLink, deployed: true, deployedGitRef: "0123abc", expectedSourceURL: "/scm/my-group/my-app/-/archive/0123abc/my-app-0123abc.tar.gz"},
+		"disabled":           {link: githubLink, deployed: true, deployedGitRef: "0123abc"},
+		"never deployed":     {redeploy: true, link: githubLink},
+		"failed deployment":  {redeploy: true, link: githubLink, deployed: true, deployedGitRef: "0123abc", deployedStatus: scalingo.StatusBuildError},
+		"not linked":         {redeploy: true, deployed: true, deployedGitRef: "0123abc", expectedError: "the application isn't linked to a SCM repository to deploy 0123abc from", expectedStack: "st-scalingo-22"},
+		"private repository": {redeploy: true, link: privateLink, deployed: true, deployedGitRef: "0123abc", expectedError: "private repositories aren't supported", expectedStack: "st-scalingo-22"},
+		"no git reference":   {redeploy: true, link: githubLink, deployed: true, expectedError: "has no git reference to deploy again", expectedStack: "st-scalingo-22"},
+		"deployment failed":  {redeploy: true, link: githubLink, deployed: true, deployedGitRef: "0123abc", deploymentStatus: scalingo.StatusBuildError, expectedError: "failed with status build-error", expectedSourceURL: "/scm/my-org/my-app/archive/0123abc.tar.gz", expectedStateStack: "st-scalingo-22"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, meta := newTestProvider(t)
+			config := map[string]any{"name": "my-app", "stack_id": "st-scalingo-22", "redeploy_on_stack_change": test.redeploy}
+			state := mustApplyResource(t, "scalingo_app", meta, nil, config)
+			app := f.findApp("my-app")
+			if test.link != nil {
+				link := *test.link
+				link.AppID = app.ID
+				link.URL = f.api.URL + link.URL
+				app.scmRepoLink = &link
+			}
+			if test.deployed {
+				params := scalingo.DeploymentsCreateParams{SourceURL: "https://example.com/v1.tar.gz"}
+				if test.deployedGitRef != "" {
+					params.GitRef = &test.deployedGitRef
+				}
+				deployment := f.createDeployment(app, params)
+				deployment.Status = scalingo.StatusSuccess
+				if test.deployedStatus != "" {
+					deployment.Status = test.deployedStatus
+				}
+			}
+			deployments := len(app.deployments)
+			f.deploymentStatus = test.deploymentStatus
+
+			config["stack_id"] = "st-scalingo-24"
+			f.privateRepos = map[string]bool{"my-org/private-app": true}
+			state, diags := applyResource(t, "scalingo_app", meta, state, config)
+			if test.expectedError != "" {
+				assertDiagContains(t, diags, test.expectedError)
+			} else if diags.HasError() {
+				t.Fatalf("apply: %v", diags)
+			}
+			expectedStack := test.expectedStack
+			if expectedStack == "" {
+				expectedStack = "st-scalingo-24"
+			}
+			if app.StackID != expectedStack {
+				t.Errorf("expected the stack to be %v, got %v", expectedStack, app.StackID)
+			}
+			// A stack change which failed is applied again by the next apply.
+			expectedStateStack := test.expectedStateStack
+			if expectedStateStack == "" {
+				expectedStateStack = expectedStack
+			}
+			assertAttr(t, state, "stack_id", expectedStateStack)
+
+			if test.expectedSourceURL == "" {
+				if len(app.deployments) != deployments {
+					t.Errorf("expected no deployment, got %d deployments", len(app.deployments)-deployments)
+				}
+				return
+			}
+			if len(app.deployments) != deployments+1 {
+				t.Fatalf("expected a new deployment, got %d deployments", len(app.deployments)-deployments)
+			}
+			deployment := app.deployments[len(app.deployments)-1]
+			if deployment.GitRef != test.deployedGitRef || deployment.StackBaseImage != "scalingo/scalingo-24" {
+				t.Errorf("expected the last deployed revision to be deployed on the new stack, got %+v", deployment.Deployment)
+			}
+			if !strings.Contains(deployment.output[0], test.expectedSourceURL) {
+				t.Errorf("expected the archive %v to be deployed, got %v", test.expectedSourceURL, deployment.output[0])
+			}
+		})
+	}
+}
+
+func TestResourceApp_DeprecatedStackWarning(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	_, diags := applyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app", "stack_id": "st-scalingo-20"})
+	if diags.HasError() || len(diags) != 1 || diags[0].Summary != "Stack scalingo-20 has been deprecated since 2025-01-01" {
+		t.Errorf("expected a warning about the deprecated stack, got %v", diags)
+	}
+
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-other-app", "stack_id": "st-scalingo-20"})
+	_, diags = refreshResource(t, "scalingo_app", meta, state)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning when refreshing, got %v", diags)
+	}
+
+	state = mustApplyResource(t, "scalingo_app", meta, state, map[string]any{"name": "my-other-app", "stack_id": "st-scalingo-22"})
+	_, diags = refreshResource(t, "scalingo_app", meta, state)
+	if len(diags) != 0 {
+		t.Errorf("expected no warning, got %v", diags)
+	}
+}
@@ -17,6 +17,7 @@ func resourceScalingoAutoscaler() *schema.Resource {
 		ReadContext:   resourceAutoscalerRead,
 		UpdateContext: resourceAutoscalerUpdate,
 		DeleteContext: resourceAutoscalerDelete,
+		CustomizeDiff: resourceAutoscalerCustomizeDiff,
 		Description:   "Resource representing an autoscaler of an application, setting rules to automatically scale up and down containers of the app",
 
 		Schema: map[string]*schema.Schema{
@@ -45,7 +46,7 @@ func resourceScalingoAutoscaler() *schema.Resource {
 			"metric": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Watched metric to base the autoscaling on (cpu, ram, etc.)",
+				Description: "Watched metric to base the autoscaling on (cpu, memory, etc.)",
 			},
 			"target": {
 				Type:        schema.TypeFloat,
@@ -65,11 +66,24 @@ func resourceScalingoAutoscaler() *schema.Resource {
 	}
 }
 
+func resourceAutoscalerCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !shouldValidate(d, "metric") {
+		return nil
+	}
+	return validateValue("metric", d.Get("metric").(string), metrics)
+}
+
 func resourceAutoscalerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	autoscaler, err := client.AutoscalerAdd(ctx, appID, scalingo.AutoscalerAddParams{
 		ContainerType: d.Get("container_type").(string),
 		Metric:        d.Get("metric").(string),
@@ -87,13 +101,17 @@ func resourceAutoscalerCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceAutoscalerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	id := d.Id()
 	appID, _ := d.Get("app").(string)
 
 	autoscaler, err := client.AutoscalerShow(ctx, appID, id)
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_autoscaler")
+			return nil
+		}
 		return diag.Errorf("fail to get autoscaler: %v", err)
 	}
 
@@ -113,10 +131,17 @@ func resourceAutoscalerRead(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceAutoscalerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	id := d.Id()
 	appID, _ := d.Get("app").(string)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	var params scalingo.AutoscalerUpdateParams
 	changed := false
 
@@ -159,8 +184,15 @@ func resourceAutoscalerDelete(ctx context.Context, d *schema.ResourceData, meta
 	id := d.Id()
 	appID, _ := d.Get("app").(string)
 
-	client, _ := meta.(*scalingo.Client)
-	err := client.AutoscalerRemove(ctx, appID, id)
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	err = client.AutoscalerRemove(ctx, appID, id)
 	if err != nil {
 		return diag.Errorf("fail to destroy autoscaler: %v", err)
 	}
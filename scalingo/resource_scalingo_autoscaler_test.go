@@ -0,0 +1,69 @@
+package scalingo
+
+import (
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceAutoscaler_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_autoscaler", meta, nil, map[string]any{
+		"app":            app.ID,
+		"container_type": "web",
+		"min_containers": 2,
+		"max_containers": 5,
+		"metric":         "cpu",
+		"target":         0.75,
+	})
+	if len(app.autoscalers) != 1 {
+		t.Fatalf("expected an autoscaler to be created, got %d", len(app.autoscalers))
+	}
+	autoscaler := app.autoscalers[0]
+	if state.ID != autoscaler.ID {
+		t.Errorf("expected ID %s, got %s", autoscaler.ID, state.ID)
+	}
+
+	state = mustRefreshResource(t, "scalingo_autoscaler", meta, state)
+	assertAttr(t, state, "min_containers", "2")
+	assertAttr(t, state, "max_containers", "5")
+	assertAttr(t, state, "target", "0.75")
+
+	state = mustApplyResource(t, "scalingo_autoscaler", meta, state, map[string]any{
+		"app":            app.ID,
+		"container_type": "web",
+		"min_containers": 3,
+		"max_containers": 10,
+		"metric":         "memory",
+		"target":         0.5,
+		"disabled":       true,
+	})
+	if autoscaler.MinContainers != 3 || autoscaler.MaxContainers != 10 || autoscaler.Metric != "memory" || !autoscaler.Disabled {
+		t.Errorf("autoscaler has not been updated: %+v", autoscaler)
+	}
+
+	mustDestroyResource(t, "scalingo_autoscaler", meta, state)
+	if len(app.autoscalers) != 0 {
+		t.Error("autoscaler has not been destroyed")
+	}
+}
+
+func TestResourceAutoscaler_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	created := mustApplyResource(t, "scalingo_autoscaler", meta, nil, map[string]any{
+		"app":            app.ID,
+		"container_type": "web",
+		"min_containers": 2,
+		"max_containers": 5,
+		"metric":         "cpu",
+		"target":         0.75,
+	})
+
+	state := mustImportResource(t, "scalingo_autoscaler", meta, app.ID+":"+created.ID)
+	assertAttr(t, state, "app", app.ID)
+	assertAttr(t, state, "container_type", "web")
+	assertAttr(t, state, "metric", "cpu")
+}
@@ -56,7 +56,13 @@ func resourceScalingoCollaborator() *schema.Resource {
 }
 
 func resourceCollaboratorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, d.Get("app").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	collaborator, err := client.CollaboratorAdd(ctx, d.Get("app").(string), scalingo.CollaboratorAddParams{
 		Email:     d.Get("email").(string),
@@ -81,10 +87,14 @@ func resourceCollaboratorCreate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceCollaboratorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	collaborators, err := client.CollaboratorsList(ctx, d.Get("app").(string))
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_collaborator")
+			return nil
+		}
 		return diag.Errorf("list collaborators: %v", err)
 	}
 
@@ -100,8 +110,7 @@ func resourceCollaboratorRead(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	if !found {
-		// Flag that it doesn't exist anymore
-		d.SetId("")
+		removeFromState(ctx, d, "scalingo_collaborator")
 		return nil
 	}
 
@@ -119,9 +128,15 @@ func resourceCollaboratorRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceCollaboratorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, d.Get("app").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
-	err := client.CollaboratorRemove(ctx, d.Get("app").(string), d.Id())
+	err = client.CollaboratorRemove(ctx, d.Get("app").(string), d.Id())
 	if err != nil {
 		return diag.Errorf("remove collaborator: %v", err)
 	}
@@ -130,7 +145,13 @@ func resourceCollaboratorDelete(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceCollaboratorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, d.Get("app").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	collaborator, err := client.CollaboratorUpdate(ctx, d.Get("app").(string), d.Id(), scalingo.CollaboratorUpdateParams{IsLimited: d.Get("limited").(bool)})
 	if err != nil {
@@ -152,7 +173,7 @@ func resourceCollaboratorUpdate(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceCollaboratorImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	ids := strings.Split(d.Id(), ":")
 	if len(ids) != 2 {
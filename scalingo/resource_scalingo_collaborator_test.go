@@ -0,0 +1,67 @@
+package scalingo
+
+import (
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceCollaborator_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_collaborator", meta, nil, map[string]any{
+		"app":   app.ID,
+		"email": "collaborator@fake.test",
+	})
+	if len(app.collaborators) != 1 {
+		t.Fatalf("expected a collaborator to be invited, got %d", len(app.collaborators))
+	}
+	collaborator := app.collaborators[0]
+	if state.ID != collaborator.ID {
+		t.Errorf("expected ID %s, got %s", collaborator.ID, state.ID)
+	}
+
+	state = mustRefreshResource(t, "scalingo_collaborator", meta, state)
+	assertAttr(t, state, "email", "collaborator@fake.test")
+	assertAttr(t, state, "status", string(collaborator.Status))
+
+	state = mustApplyResource(t, "scalingo_collaborator", meta, state, map[string]any{
+		"app":     app.ID,
+		"email":   "collaborator@fake.test",
+		"limited": true,
+	})
+	assertAttr(t, state, "limited", "true")
+	if !collaborator.IsLimited {
+		t.Error("expected the collaborator to be limited")
+	}
+
+	mustDestroyResource(t, "scalingo_collaborator", meta, state)
+	if len(app.collaborators) != 0 {
+		t.Error("collaborator has not been removed")
+	}
+
+	// The collaborator is removed from the state once it's gone.
+	gone, diags := refreshResource(t, "scalingo_collaborator", meta, state)
+	if diags.HasError() {
+		t.Fatalf("refresh: %v", diags)
+	}
+	if gone != nil {
+		t.Errorf("expected the collaborator to be removed from the state, got %v", gone)
+	}
+}
+
+func TestResourceCollaborator_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	created := mustApplyResource(t, "scalingo_collaborator", meta, nil, map[string]any{
+		"app":   app.ID,
+		"email": "collaborator@fake.test",
+	})
+
+	state := mustImportResource(t, "scalingo_collaborator", meta, app.ID+":collaborator@fake.test")
+	if state.ID != created.ID {
+		t.Errorf("expected ID %s, got %s", created.ID, state.ID)
+	}
+	assertAttr(t, state, "app", app.ID)
+}
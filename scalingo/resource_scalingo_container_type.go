@@ -4,20 +4,36 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/Scalingo/go-scalingo/v11"
 )
 
+// scaleTimeout is the default timeout of the scaling of a container type.
+const scaleTimeout = 10 * time.Minute
+
+// States of the containers returned by AppsContainersPs.
+const (
+	containerStateRunning = "running"
+	containerStateCrashed = "crashed"
+)
+
 func resourceScalingoContainerType() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceContainerTypeCreate,
 		ReadContext:   resourceContainerTypeRead,
 		UpdateContext: resourceContainerTypeUpdate,
 		DeleteContext: resourceContainerTypeDelete,
+		CustomizeDiff: resourceContainerTypeCustomizeDiff,
 		Description:   "Resource representing a container type, allowing to scale an application containers",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(scaleTimeout),
+			Update: schema.DefaultTimeout(scaleTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"app": {
@@ -32,9 +48,12 @@ func resourceScalingoContainerType() *schema.Resource {
 				Description: "Name of the container type",
 			},
 			"amount": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Number of containers to boot for this type",
+				Type: schema.TypeInt,
+				// The amount is required, it is only computed so that the plan
+				// can keep the amount set by an autoscaler.
+				Optional:    true,
+				Computed:    true,
+				Description: "Number of containers to boot for this type, required. If the container type has an enabled autoscaler, it must be in the range of the autoscaler and the changes of the autoscaler in this range are not reported as drift",
 			},
 			"size": {
 				Type:        schema.TypeString,
@@ -50,20 +69,20 @@ func resourceScalingoContainerType() *schema.Resource {
 }
 
 func resourceContainerTypeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 	ctName, _ := d.Get("name").(string)
 
-	_, _, err := client.AppsScale(ctx, appID, &scalingo.AppsScaleParams{
-		Containers: []scalingo.ContainerType{{
-			Name:   ctName,
-			Size:   d.Get("size").(string),
-			Amount: d.Get("amount").(int),
-		}},
-	})
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	err = scaleContainerType(ctx, client, d, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return diag.Errorf("fail to scale application: %v", err)
+		return diag.FromErr(err)
 	}
 
 	d.SetId(appID + ":" + ctName)
@@ -72,7 +91,7 @@ func resourceContainerTypeCreate(ctx context.Context, d *schema.ResourceData, me
 }
 
 func resourceContainerTypeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 	ctName, _ := d.Get("name").(string)
@@ -80,6 +99,10 @@ func resourceContainerTypeRead(ctx context.Context, d *schema.ResourceData, meta
 
 	containers, err := client.AppsContainerTypes(ctx, appID)
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_container_type")
+			return nil
+		}
 		return diag.Errorf("fail to list container types: %v", err)
 	}
 
@@ -100,29 +123,212 @@ func resourceContainerTypeRead(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceContainerTypeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 	ctName, _ := d.Get("name").(string)
 
-	_, _, err := client.AppsScale(ctx, appID, &scalingo.AppsScaleParams{
-		Containers: []scalingo.ContainerType{{
-			Name:   ctName,
-			Size:   d.Get("size").(string),
-			Amount: d.Get("amount").(int),
-		}},
-	})
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	amount, _ := d.Get("amount").(int)
+	if !d.HasChange("amount") {
+		// The amount in the state may not be the current one if the container
+		// type is autoscaled: changing the size mustn't scale it back.
+		containers, err := client.AppsContainerTypes(ctx, appID)
+		if err != nil {
+			return diag.Errorf("list container types: %v", err)
+		}
+		for _, ct := range containers {
+			if ct.Name == ctName {
+				amount = ct.Amount
+			}
+		}
+	}
+
+	err = scaleContainers(ctx, client, appID, []scalingo.ContainerType{{
+		Name:   ctName,
+		Size:   d.Get("size").(string),
+		Amount: amount,
+	}}, d.Timeout(schema.TimeoutUpdate))
 	if err != nil {
-		return diag.Errorf("fail to scale application: %v", err)
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
+// scaleContainerType scales the container type to the amount and size of the
+// resource, and waits for its containers to be running.
+func scaleContainerType(ctx context.Context, client *providerMeta, d *schema.ResourceData, timeout time.Duration) error {
+	return scaleContainers(ctx, client, d.Get("app").(string), []scalingo.ContainerType{{
+		Name:   d.Get("name").(string),
+		Size:   d.Get("size").(string),
+		Amount: d.Get("amount").(int),
+	}}, timeout)
+}
+
+// scaleContainers scales the given container types of the application in a
+// single request. It waits for the scale operation to be done, then for the
+// requested amount of containers to be running.
+func scaleContainers(ctx context.Context, client *providerMeta, appID string, containerTypes []scalingo.ContainerType, timeout time.Duration) error {
+	if len(containerTypes) == 0 {
+		return nil
+	}
+
+	_, location, err := client.AppsScale(ctx, appID, &scalingo.AppsScaleParams{Containers: containerTypes})
+	if err != nil {
+		return fmt.Errorf("scale application: %w", err)
+	}
+
+	names := make([]string, 0, len(containerTypes))
+	for _, ct := range containerTypes {
+		names = append(names, ct.Name)
+	}
+	if location != "" {
+		err = waitOperation(ctx, client, location, fmt.Sprintf("the scaling of the %v containers", strings.Join(names, ", ")), timeout)
+		if err != nil {
+			return fmt.Errorf("wait for the application to be scaled: %v", err)
+		}
+	}
+
+	err = waitContainersRunning(ctx, client, appID, containerTypes, timeout)
+	if err != nil {
+		return fmt.Errorf("wait for the %v containers to be running: %v", strings.Join(names, ", "), err)
+	}
+	return nil
+}
+
+// waitContainersRunning waits for the given container types to run exactly
+// their amount of containers, of their size if any. It fails as soon as one
+// of these containers crashed.
+func waitContainersRunning(ctx context.Context, client *providerMeta, appID string, containerTypes []scalingo.ContainerType, timeout time.Duration) error {
+	requested := make(map[string]scalingo.ContainerType, len(containerTypes))
+	for _, ct := range containerTypes {
+		requested[ct.Name] = ct
+	}
+
+	var running map[string]int
+	return waitUntil(ctx, waitOptions{
+		timeout:   timeout,
+		operation: "the containers to be running",
+		status: func() string {
+			statuses := make([]string, 0, len(containerTypes))
+			for _, ct := range containerTypes {
+				statuses = append(statuses, fmt.Sprintf("%d/%d %v", running[ct.Name], ct.Amount, ct.Name))
+			}
+			return strings.Join(statuses, ", ") + " running"
+		},
+	}, func() (bool, error) {
+		containers, err := client.AppsContainersPs(ctx, appID)
+		if err != nil {
+			return false, fmt.Errorf("list containers: %w", err)
+		}
+
+		running = map[string]int{}
+		var crashed []string
+		for _, container := range containers {
+			ct, ok := requested[container.Type]
+			if !ok {
+				continue
+			}
+			switch container.State {
+			case containerStateRunning:
+				if ct.Size == "" || container.ContainerSize.Name == ct.Size {
+					running[ct.Name]++
+				}
+			case containerStateCrashed:
+				crashed = append(crashed, fmt.Sprintf("%v-%d", container.Type, container.TypeIndex))
+			}
+		}
+		if len(crashed) > 0 {
+			return false, fmt.Errorf("containers %v crashed, check the logs of the application", strings.Join(crashed, ", "))
+		}
+
+		for _, ct := range containerTypes {
+			if running[ct.Name] != ct.Amount {
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
 func resourceContainerTypeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
 
+func resourceContainerTypeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	client, _ := meta.(*providerMeta)
+
+	if shouldValidate(d, "size") {
+		err := validateContainerSize(ctx, client, d.Get("size").(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	// amount is only computed so that its change can be cleared below, it is
+	// still required.
+	if config := d.GetRawConfig(); !config.IsNull() && config.GetAttr("amount").IsNull() {
+		return fmt.Errorf("amount is required")
+	}
+
+	if !d.NewValueKnown("amount") || !d.NewValueKnown("app") || !d.NewValueKnown("name") {
+		return nil
+	}
+	appID, _ := d.Get("app").(string)
+	ctName, _ := d.Get("name").(string)
+	autoscaler, err := enabledAutoscaler(ctx, client, appID, ctName)
+	if err != nil {
+		if isNotFoundError(err) {
+			// The application is created by the same apply.
+			return nil
+		}
+		return err
+	}
+	if autoscaler == nil {
+		return nil
+	}
+
+	oldAmount, newAmount := d.GetChange("amount")
+	amount, _ := newAmount.(int)
+	if !inAutoscalerRange(autoscaler, amount) {
+		return fmt.Errorf("amount %d of container type %v is outside the range of its autoscaler: it must be between %d and %d",
+			amount, ctName, autoscaler.MinContainers, autoscaler.MaxContainers)
+	}
+	// The amount of an autoscaled container type changes constantly: the
+	// current amount is kept as long as it is in the range of the autoscaler,
+	// so that the plan doesn't scale it back.
+	if d.Id() != "" && d.HasChange("amount") && inAutoscalerRange(autoscaler, oldAmount.(int)) {
+		tflog.Debug(ctx, fmt.Sprintf("Ignoring the change of the amount of the autoscaled container type %s from %d to %d", ctName, oldAmount, amount))
+		return d.Clear("amount")
+	}
+	return nil
+}
+
+// enabledAutoscaler returns the enabled autoscaler of the container type of
+// the application, nil if there is none.
+func enabledAutoscaler(ctx context.Context, client *providerMeta, appID, ctName string) (*scalingo.Autoscaler, error) {
+	autoscalers, err := client.AutoscalersList(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("list autoscalers: %w", err)
+	}
+	for _, autoscaler := range autoscalers {
+		if autoscaler.ContainerType == ctName && !autoscaler.Disabled {
+			return &autoscaler, nil
+		}
+	}
+	return nil, nil
+}
+
+func inAutoscalerRange(autoscaler *scalingo.Autoscaler, amount int) bool {
+	return amount >= autoscaler.MinContainers && amount <= autoscaler.MaxContainers
+}
+
 // resourceContainerTypeImport is called when importing a new container_type
 // resource. The ID must be "appID:containerTypeName" such as
 // "5a155aa8f112e20010779b7a:web".
@@ -140,7 +346,7 @@ func resourceContainerTypeImport(ctx context.Context, d *schema.ResourceData, me
 	appID := ids[0]
 	ctName := ids[1]
 
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 	containers, err := client.AppsContainerTypes(ctx, appID)
 	if err != nil {
 		return nil, fmt.Errorf("fail to list container types: %v", err)
@@ -0,0 +1,279 @@
+package scalingo
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceContainerType_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+		"app":    app.ID,
+		"name":   "worker",
+		"amount": 2,
+		"size":   "L",
+	})
+	if state.ID != app.ID+":worker" {
+		t.Errorf("expected ID %s:worker, got %s", app.ID, state.ID)
+	}
+	if len(app.containers) != 2 || app.containers[1].Amount != 2 || app.containers[1].Size != "L" {
+		t.Fatalf("expected a worker container type to be scaled, got %+v", app.containers)
+	}
+
+	state = mustRefreshResource(t, "scalingo_container_type", meta, state)
+	assertAttr(t, state, "amount", "2")
+	assertAttr(t, state, "size", "L")
+
+	state = mustApplyResource(t, "scalingo_container_type", meta, state, map[string]any{
+		"app":    app.ID,
+		"name":   "worker",
+		"amount": 4,
+		"size":   "XL",
+	})
+	if app.containers[1].Amount != 4 || app.containers[1].Size != "XL" {
+		t.Errorf("expected the worker container type to be scaled, got %+v", app.containers[1])
+	}
+
+	// Destroying the resource doesn't scale the containers down.
+	mustDestroyResource(t, "scalingo_container_type", meta, state)
+	if app.containers[1].Amount != 4 {
+		t.Errorf("expected the containers to be left untouched, got %+v", app.containers[1])
+	}
+}
+
+func TestResourceContainerType_AmountRequired(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	config := map[string]any{"app": app.ID, "name": "web"}
+
+	state := mustImportResource(t, "scalingo_container_type", meta, app.ID+":web")
+	state.RawConfig = cty.ObjectVal(map[string]cty.Value{
+		"app":    cty.StringVal(app.ID),
+		"name":   cty.StringVal("web"),
+		"amount": cty.NullVal(cty.Number),
+	})
+	_, diags := planResource(t, "scalingo_container_type", meta, state, config)
+	assertDiagContains(t, diags, "amount is required")
+}
+
+func TestResourceContainerType_AmountRequiredWithTerraform(t *testing.T) {
+	f := newFakeAPI(t)
+	appConfig := testProviderConfig(f) + `
+resource "scalingo_app" "app" {
+  name = "my-app"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testTerraformPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: appConfig + `
+resource "scalingo_container_type" "web" {
+  app    = scalingo_app.app.id
+  name   = "web"
+  amount = 2
+}
+`,
+				Check: resource.TestCheckResourceAttr("scalingo_container_type.web", "amount", "2"),
+			},
+			{
+				// amount is computed in the schema, only the plan checks that
+				// it is set.
+				Config: appConfig + `
+resource "scalingo_container_type" "web" {
+  app  = scalingo_app.app.id
+  name = "web"
+}
+`,
+				ExpectError: regexp.MustCompile(`amount\s+is\s+required`),
+			},
+		},
+	})
+}
+
+func TestResourceContainerType_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustImportResource(t, "scalingo_container_type", meta, app.ID+":web")
+	assertAttr(t, state, "app", app.ID)
+	assertAttr(t, state, "name", "web")
+	assertAttr(t, state, "amount", "1")
+	assertAttr(t, state, "size", "M")
+
+	_, err := importResource(t, "scalingo_container_type", meta, app.ID+":clock")
+	if err == nil {
+		t.Error("expected an error when importing an unknown container type")
+	}
+}
+
+func TestResourceContainerType_WaitsForScaleOperation(t *testing.T) {
+	t.Run("done", func(t *testing.T) {
+		f, meta := newTestProvider(t)
+		f.pendingPolls = 2
+		app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+		mustApplyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+			"app": app.ID, "name": "web", "amount": 3,
+		})
+		op := app.operations[0]
+		if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID+"/operations/"+op.ID); count != 3 {
+			t.Errorf("expected the scale operation to be polled until done, got %d requests", count)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		f, meta := newTestProvider(t)
+		f.operationError = "not enough capacity"
+		app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+		_, diags := applyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+			"app": app.ID, "name": "web", "amount": 3,
+		})
+		assertDiagContains(t, diags, "the scaling of the web containers failed: not enough capacity")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		f, meta := newTestProvider(t)
+		f.pendingPolls = 1000
+		app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+		_, diags := applyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+			"app": app.ID, "name": "web", "amount": 3,
+			"timeouts": map[string]any{"create": "50ms"},
+		})
+		assertDiagContains(t, diags, "waiting for the scaling of the web containers (last status: running)")
+	})
+}
+
+func TestResourceContainerType_WaitsForRunningContainers(t *testing.T) {
+	t.Run("booting", func(t *testing.T) {
+		f, meta := newTestProvider(t)
+		app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+		f.pendingPolls = 2
+
+		mustApplyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+			"app": app.ID, "name": "web", "amount": 3,
+		})
+		if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID+"/ps"); count != 3 {
+			t.Errorf("expected the containers to be listed until running, got %d requests", count)
+		}
+	})
+
+	t.Run("crashed", func(t *testing.T) {
+		f, meta := newTestProvider(t)
+		app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+		app.containerStates = map[string]string{"web": "crashed"}
+
+		_, diags := applyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+			"app": app.ID, "name": "web", "amount": 2,
+		})
+		assertDiagContains(t, diags, "wait for the web containers to be running: containers web-1, web-2 crashed")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		f, meta := newTestProvider(t)
+		app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+		app.containerStates = map[string]string{"web": "booting"}
+
+		_, diags := applyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+			"app": app.ID, "name": "web", "amount": 2,
+			"timeouts": map[string]any{"create": "50ms"},
+		})
+		assertDiagContains(t, diags, "waiting for the containers to be running (last status: 0/2 web running)")
+	})
+}
+
+func TestResourceContainerType_Autoscaled(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	app.autoscalers = append(app.autoscalers, &scalingo.Autoscaler{
+		ID: "as-1", AppID: app.ID, ContainerType: "web", Metric: "cpu", Target: 0.8, MinContainers: 2, MaxContainers: 5,
+	})
+	config := map[string]any{"app": app.ID, "name": "web", "amount": 2, "size": "M"}
+
+	state := mustApplyResource(t, "scalingo_container_type", meta, nil, config)
+
+	// The autoscaler scaled the containers up.
+	app.containers[0].Amount = 4
+	autoscalersLists := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID+"/autoscalers")
+	state = mustRefreshResource(t, "scalingo_container_type", meta, state)
+	assertAttr(t, state, "amount", "4")
+	if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID+"/autoscalers"); count != autoscalersLists {
+		t.Errorf("expected the refresh not to list the autoscalers, got %d requests", count-autoscalersLists)
+	}
+	diff, diags := planResource(t, "scalingo_container_type", meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected the amount drift in the range of the autoscaler to be ignored, got %v", diff)
+	}
+
+	// Changing the size keeps the amount set by the autoscaler.
+	state = mustApplyResource(t, "scalingo_container_type", meta, state, map[string]any{
+		"app": app.ID, "name": "web", "amount": 2, "size": "L",
+	})
+	if app.containers[0].Amount != 4 || app.containers[0].Size != "L" {
+		t.Errorf("expected the container type to be resized without being scaled, got %+v", app.containers[0])
+	}
+
+	// A drift outside of the range of the autoscaler is reported.
+	app.containers[0].Amount = 8
+	state = mustRefreshResource(t, "scalingo_container_type", meta, state)
+	assertAttr(t, state, "amount", "8")
+	diff, diags = planResource(t, "scalingo_container_type", meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff == nil || diff.Attributes["amount"] == nil || diff.Attributes["amount"].New != "2" {
+		t.Errorf("expected the container type to be scaled back in the range of the autoscaler, got %v", diff)
+	}
+
+	// A disabled autoscaler doesn't own the amount anymore.
+	app.containers[0].Amount = 4
+	app.autoscalers[0].Disabled = true
+	state = mustRefreshResource(t, "scalingo_container_type", meta, state)
+	assertAttr(t, state, "amount", "4")
+}
+
+func TestResourceContainerType_AmountOutsideAutoscalerRange(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	app.autoscalers = append(app.autoscalers, &scalingo.Autoscaler{
+		ID: "as-1", AppID: app.ID, ContainerType: "web", Metric: "cpu", Target: 0.8, MinContainers: 2, MaxContainers: 5,
+	})
+
+	_, diags := applyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+		"app": app.ID, "name": "web", "amount": 6,
+	})
+	assertDiagContains(t, diags, "amount 6 of container type web is outside the range of its autoscaler: it must be between 2 and 5")
+	if count := f.requestCount(http.MethodPost, "/v1/apps/"+app.ID+"/scale"); count != 0 {
+		t.Errorf("expected no scale request, got %d", count)
+	}
+
+	// The range is checked even if the amount doesn't change.
+	state := mustApplyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+		"app": app.ID, "name": "web", "amount": 3,
+	})
+	app.autoscalers[0].MinContainers = 4
+	_, diags = planResource(t, "scalingo_container_type", meta, state, map[string]any{
+		"app": app.ID, "name": "web", "amount": 3,
+	})
+	assertDiagContains(t, diags, "amount 3 of container type web is outside the range of its autoscaler: it must be between 4 and 5")
+
+	// The autoscaler of another container type is not considered.
+	mustApplyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+		"app": app.ID, "name": "worker", "amount": 6,
+	})
+}
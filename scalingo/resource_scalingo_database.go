@@ -22,6 +22,7 @@ func resourceScalingoDatabase() *schema.Resource {
 		ReadContext:   resourceDatabaseRead,
 		UpdateContext: resourceDatabaseUpdate,
 		DeleteContext: resourceDatabaseDelete,
+		CustomizeDiff: resourceDatabaseCustomizeDiff,
 		Description:   "Resource representing a Database NG on Scalingo",
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(provisioningTimeout),
@@ -61,6 +62,11 @@ func resourceScalingoDatabase() *schema.Resource {
 				Computed:    true,
 				Description: "ID of the Database NG on DBAPI side",
 			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Prevent the Database NG and its data from being destroyed or replaced, the deletion fails until it is disabled by a prior apply",
+			},
 		},
 
 		Importer: &schema.ResourceImporter{
@@ -70,8 +76,8 @@ func resourceScalingoDatabase() *schema.Resource {
 }
 
 func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
-	previewClient := scalingo.NewPreviewClient(client)
+	client, _ := meta.(*providerMeta)
+	previewClient := scalingo.NewPreviewClient(client.Client)
 
 	//nolint:errcheck // type assertions cannot fail it's defined in the schema.
 	var (
@@ -101,7 +107,7 @@ func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.Errorf("provision database: %v", err)
 	}
 
-	res, err = waitUntilDatabaseProvisioned(ctx, client, res)
+	res, err = waitUntilDatabaseProvisioned(ctx, client, res, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
 		return diag.Errorf("wait for the addon to be provisioned: %v", err)
 	}
@@ -117,11 +123,15 @@ func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceDatabaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
-	previewClient := scalingo.NewPreviewClient(client)
+	client, _ := meta.(*providerMeta)
+	previewClient := scalingo.NewPreviewClient(client.Client)
 
 	database, err := previewClient.DatabaseShow(ctx, d.Id())
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_database")
+			return nil
+		}
 		return diag.Errorf("get database details: %v", err)
 	}
 
@@ -148,8 +158,14 @@ func resourceDatabaseRead(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
-	previewClient := scalingo.NewPreviewClient(client)
+	client, _ := meta.(*providerMeta)
+	previewClient := scalingo.NewPreviewClient(client.Client)
+
+	unlock, err := client.lockApp(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	database, err := previewClient.DatabaseShow(ctx, d.Id())
 	if err != nil {
@@ -211,7 +227,7 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta in
 			return diag.Errorf("upgrade database: %v", err)
 		}
 
-		database, err = waitUntilDatabasePlanChanged(ctx, client, database)
+		database, err = waitUntilDatabasePlanChanged(ctx, client, database, d.Timeout(schema.TimeoutUpdate))
 		if err != nil {
 			return diag.Errorf("wait for database provisioning: %v", err)
 		}
@@ -225,14 +241,24 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
 
 	name, ok := d.Get("name").(string)
 	if !ok {
 		return diag.Errorf("name must be a string")
 	}
 
-	err := client.AppsDestroy(ctx, d.Id(), name)
+	if deletionProtection, _ := d.Get("deletion_protection").(bool); deletionProtection {
+		return deletionProtectionError("Database NG", name)
+	}
+
+	err = client.AppsDestroy(ctx, d.Id(), name)
 	if err != nil {
 		return diag.Errorf("destroy database: %v", err)
 	}
@@ -240,8 +266,8 @@ func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceDatabaseImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	client, _ := meta.(*scalingo.Client)
-	previewClient := scalingo.NewPreviewClient(client)
+	client, _ := meta.(*providerMeta)
+	previewClient := scalingo.NewPreviewClient(client.Client)
 
 	// d.Id() contains the database name provided by the user during import corresponding to the app name
 	// We need to find the App ID associated with this app name to retrieve the database
@@ -269,15 +295,27 @@ func resourceDatabaseImport(ctx context.Context, d *schema.ResourceData, meta in
 	return []*schema.ResourceData{d}, nil
 }
 
-func waitUntilDatabasePlanChanged(ctx context.Context, client *scalingo.Client, scalingoDatabase scalingo.DatabaseNG) (scalingo.DatabaseNG, error) {
-	previewClient := scalingo.NewPreviewClient(client)
+func resourceDatabaseCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !shouldValidate(d, "plan") || !d.NewValueKnown("technology") {
+		return nil
+	}
+	client, _ := meta.(*providerMeta)
+
+	return validateAddonPlan(ctx, client, d.Get("technology").(string), d.Get("plan").(string))
+}
+
+func waitUntilDatabasePlanChanged(ctx context.Context, client *providerMeta, scalingoDatabase scalingo.DatabaseNG, timeout time.Duration) (scalingo.DatabaseNG, error) {
+	previewClient := scalingo.NewPreviewClient(client.Client)
 
 	// First, wait for the database to start updating (status != running)
 	if scalingoDatabase.Database.Status == scalingo.DatabaseStatusRunning {
 		var err error
 		err = waitUntil(ctx, waitOptions{
-			timeout:    provisioningTimeout,
-			timeoutErr: errors.New("database plan change timed out waiting for update to start"),
+			timeout:   timeout,
+			operation: "the database plan change to start",
+			status: func() string {
+				return string(scalingoDatabase.Database.Status)
+			},
 		}, func() (bool, error) {
 			scalingoDatabase, err = previewClient.DatabaseShow(ctx, scalingoDatabase.App.ID)
 			if err != nil {
@@ -291,16 +329,19 @@ func waitUntilDatabasePlanChanged(ctx context.Context, client *scalingo.Client,
 	}
 
 	// Then wait for the database to be running again
-	return waitUntilDatabaseProvisioned(ctx, client, scalingoDatabase)
+	return waitUntilDatabaseProvisioned(ctx, client, scalingoDatabase, timeout)
 }
 
-func waitUntilDatabaseProvisioned(ctx context.Context, client *scalingo.Client, scalingoDatabase scalingo.DatabaseNG) (scalingo.DatabaseNG, error) {
-	previewClient := scalingo.NewPreviewClient(client)
+func waitUntilDatabaseProvisioned(ctx context.Context, client *providerMeta, scalingoDatabase scalingo.DatabaseNG, timeout time.Duration) (scalingo.DatabaseNG, error) {
+	previewClient := scalingo.NewPreviewClient(client.Client)
 
 	var err error
 	err = waitUntil(ctx, waitOptions{
-		timeout:    provisioningTimeout,
-		timeoutErr: errors.New("database provisioning timed out"),
+		timeout:   timeout,
+		operation: "the database to be provisioned",
+		status: func() string {
+			return string(scalingoDatabase.Database.Status)
+		},
 	}, func() (bool, error) {
 		scalingoDatabase, err = previewClient.DatabaseShow(ctx, scalingoDatabase.App.ID)
 		if err != nil {
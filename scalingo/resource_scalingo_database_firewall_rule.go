@@ -62,8 +62,8 @@ func resourceScalingoDatabaseFirewallRule() *schema.Resource {
 }
 
 func resourceDatabaseFirewallRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
-	previewClient := scalingo.NewPreviewClient(client)
+	client, _ := meta.(*providerMeta)
+	previewClient := scalingo.NewPreviewClient(client.Client)
 
 	databaseID, _ := d.Get("database_id").(string)
 	cidr, _ := d.Get("cidr").(string)
@@ -117,23 +117,31 @@ func resourceDatabaseFirewallRuleCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceDatabaseFirewallRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
-	previewClient := scalingo.NewPreviewClient(client)
+	client, _ := meta.(*providerMeta)
+	previewClient := scalingo.NewPreviewClient(client.Client)
 
 	databaseID, _ := d.Get("database_id").(string)
 
 	appID, addonID, err := getDBAPIContext(ctx, client, databaseID)
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_database_firewall_rule")
+			return nil
+		}
 		return diag.Errorf("resolve database context: %v", err)
 	}
 
 	selected, err := findFirewallRule(ctx, previewClient, appID, addonID, d.Id())
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_database_firewall_rule")
+			return nil
+		}
 		return diag.Errorf("list firewall rules: %v", err)
 	}
 
 	if selected == nil {
-		d.SetId("")
+		removeFromState(ctx, d, "scalingo_database_firewall_rule")
 		return nil
 	}
 
@@ -152,8 +160,8 @@ func resourceDatabaseFirewallRuleRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceDatabaseFirewallRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
-	previewClient := scalingo.NewPreviewClient(client)
+	client, _ := meta.(*providerMeta)
+	previewClient := scalingo.NewPreviewClient(client.Client)
 
 	databaseID, _ := d.Get("database_id").(string)
 
@@ -0,0 +1,85 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestResourceDatabaseFirewallRule_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	database := mustApplyResource(t, "scalingo_database", meta, nil, map[string]any{
+		"name":       "my-db",
+		"technology": "postgresql-ng",
+		"plan":       "postgresql-ng-starter-4096",
+	})
+	addonID := f.findApp("my-db").addons[0].ID
+
+	custom := mustApplyResource(t, "scalingo_database_firewall_rule", meta, nil, map[string]any{
+		"database_id": database.ID,
+		"cidr":        "203.0.113.0/24",
+		"label":       "office",
+	})
+	assertAttr(t, custom, "rule_type", "custom_range")
+
+	managed := mustApplyResource(t, "scalingo_database_firewall_rule", meta, nil, map[string]any{
+		"database_id":      database.ID,
+		"managed_range_id": "mr-scalingo",
+	})
+	assertAttr(t, managed, "rule_type", "managed_range")
+
+	if rules := f.databases[addonID].firewallRules; len(rules) != 2 {
+		t.Fatalf("expected 2 firewall rules, got %d", len(rules))
+	}
+
+	custom = mustRefreshResource(t, "scalingo_database_firewall_rule", meta, custom)
+	assertAttr(t, custom, "cidr", "203.0.113.0/24")
+	assertAttr(t, custom, "label", "office")
+
+	mustDestroyResource(t, "scalingo_database_firewall_rule", meta, custom)
+	if rules := f.databases[addonID].firewallRules; len(rules) != 1 {
+		t.Errorf("expected a single firewall rule left, got %d", len(rules))
+	}
+
+	// The rule is removed from the state once it's gone.
+	gone, diags := refreshResource(t, "scalingo_database_firewall_rule", meta, custom)
+	if diags.HasError() {
+		t.Fatalf("refresh: %v", diags)
+	}
+	if gone != nil {
+		t.Errorf("expected the rule to be removed from the state, got %v", gone)
+	}
+}
+
+func TestResourceDatabaseFirewallRule_InvalidConfig(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	_, diags := applyResource(t, "scalingo_database_firewall_rule", meta, nil, map[string]any{
+		"database_id":      "my-db",
+		"cidr":             "203.0.113.0/24",
+		"managed_range_id": "mr-scalingo",
+	})
+	if !diags.HasError() {
+		t.Error("expected cidr and managed_range_id to conflict")
+	}
+}
+
+func TestResourceDatabaseFirewallRule_Import(t *testing.T) {
+	_, meta := newTestProvider(t)
+	database := mustApplyResource(t, "scalingo_database", meta, nil, map[string]any{
+		"name":       "my-db",
+		"technology": "postgresql-ng",
+		"plan":       "postgresql-ng-starter-4096",
+	})
+	created := mustApplyResource(t, "scalingo_database_firewall_rule", meta, nil, map[string]any{
+		"database_id": database.ID,
+		"cidr":        "203.0.113.0/24",
+	})
+
+	state := mustImportResource(t, "scalingo_database_firewall_rule", meta, database.ID+":"+created.ID)
+	assertAttr(t, state, "database_id", database.ID)
+	assertAttr(t, state, "cidr", "203.0.113.0/24")
+
+	_, err := importResource(t, "scalingo_database_firewall_rule", meta, created.ID)
+	if err == nil {
+		t.Error("expected an error for an import ID without database")
+	}
+}
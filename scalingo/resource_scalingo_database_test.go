@@ -0,0 +1,139 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestResourceDatabase_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.pendingPolls = 2
+
+	state := mustApplyResource(t, "scalingo_database", meta, nil, map[string]any{
+		"name":       "my-db",
+		"technology": "postgresql-ng",
+		"plan":       "postgresql-ng-starter-4096",
+	})
+	app := f.findApp("my-db")
+	if app == nil || app.databaseNG == nil {
+		t.Fatal("database has not been created")
+	}
+	if state.ID != app.ID {
+		t.Errorf("expected ID %s, got %s", app.ID, state.ID)
+	}
+	assertAttr(t, state, "plan_id", "pl-pgng-starter")
+	assertAttr(t, state, "database_id", app.addons[0].ID)
+
+	state = mustRefreshResource(t, "scalingo_database", meta, state)
+	assertAttr(t, state, "name", "my-db")
+	assertAttr(t, state, "technology", "postgresql-ng")
+	assertAttr(t, state, "plan", "postgresql-ng-starter-4096")
+
+	state = mustApplyResource(t, "scalingo_database", meta, state, map[string]any{
+		"name":       "my-renamed-db",
+		"technology": "postgresql-ng",
+		"plan":       "postgresql-ng-business-4096",
+		"project_id": "pr-other",
+	})
+	assertAttr(t, state, "name", "my-renamed-db")
+	assertAttr(t, state, "plan", "postgresql-ng-business-4096")
+	assertAttr(t, state, "plan_id", "pl-pgng-business")
+	assertAttr(t, state, "project_id", "pr-other")
+	if app.Name != "my-renamed-db" {
+		t.Errorf("expected the database application to be renamed, got %s", app.Name)
+	}
+
+	mustDestroyResource(t, "scalingo_database", meta, state)
+	if f.findApp(app.ID) != nil {
+		t.Error("database has not been destroyed")
+	}
+}
+
+func TestResourceDatabase_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	created := mustApplyResource(t, "scalingo_database", meta, nil, map[string]any{
+		"name":       "my-db",
+		"technology": "postgresql-ng",
+		"plan":       "postgresql-ng-starter-4096",
+	})
+
+	state := mustImportResource(t, "scalingo_database", meta, "my-db")
+	if state.ID != created.ID {
+		t.Errorf("expected ID %s, got %s", created.ID, state.ID)
+	}
+	assertAttr(t, state, "plan_id", "pl-pgng-starter")
+	assertAttr(t, state, "database_id", f.findApp("my-db").addons[0].ID)
+
+	diff, diags := planResource(t, "scalingo_database", meta, state, map[string]any{
+		"name":       "my-db",
+		"technology": "postgresql-ng",
+		"plan":       "postgresql-ng-starter-4096",
+	})
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no change after the import, got %v", diff)
+	}
+}
+
+func TestResourceDatabase_InvalidPlan(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	_, diags := applyResource(t, "scalingo_database", meta, nil, map[string]any{
+		"name":       "my-db",
+		"technology": "postgresql-ng",
+		"plan":       "postgresql-ng-enterprise",
+	})
+	assertDiagContains(t, diags, `"postgresql-ng-enterprise" is not a valid plan for addon provider postgresql-ng`)
+	if f.findApp("my-db") != nil {
+		t.Error("no database should have been created")
+	}
+}
+
+func TestResourceDatabase_ProvisioningTimeout(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.pendingPolls = 1000
+
+	_, diags := applyResource(t, "scalingo_database", meta, nil, map[string]any{
+		"name":       "my-db",
+		"technology": "postgresql-ng",
+		"plan":       "postgresql-ng-starter-4096",
+		"timeouts":   map[string]any{"create": "50ms"},
+	})
+	assertDiagContains(t, diags, "waiting for the database to be provisioned (last status: creating)")
+}
+
+func TestResourceDatabase_DeletionProtection(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	config := map[string]any{
+		"name":                "my-db",
+		"technology":          "postgresql-ng",
+		"plan":                "postgresql-ng-starter-4096",
+		"deletion_protection": true,
+	}
+	state := mustApplyResource(t, "scalingo_database", meta, nil, config)
+
+	// Changing the technology replaces the database.
+	config["technology"] = "postgresql"
+	config["plan"] = "postgresql-starter-512"
+	_, diags := applyResource(t, "scalingo_database", meta, state, config)
+	assertDiagContains(t, diags, "Database NG my-db is protected against deletion")
+	if f.findApp("my-db") == nil {
+		t.Fatal("expected the database not to be destroyed")
+	}
+
+	diags = destroyResource(t, "scalingo_database", meta, state)
+	assertDiagContains(t, diags, "Database NG my-db is protected against deletion")
+
+	config = map[string]any{
+		"name":       "my-db",
+		"technology": "postgresql-ng",
+		"plan":       "postgresql-ng-starter-4096",
+	}
+	state = mustApplyResource(t, "scalingo_database", meta, state, config)
+	mustDestroyResource(t, "scalingo_database", meta, state)
+	if f.findApp("my-db") != nil {
+		t.Error("expected the database to be destroyed")
+	}
+}
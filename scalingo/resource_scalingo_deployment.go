@@ -0,0 +1,182 @@
+package scalingo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+// deploymentTimeout is the default timeout of a deployment, from the build of
+// the image to the boot of the new containers.
+const deploymentTimeout = 30 * time.Minute
+
+func resourceScalingoDeployment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDeploymentCreate,
+		ReadContext:   resourceDeploymentRead,
+		DeleteContext: resourceDeploymentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDeploymentImport,
+		},
+		Description: "Resource representing a deployment of an application from a source code archive. " +
+			"Changing any argument triggers a new deployment. " +
+			"Destroying the resource only removes it from the state: a deployment can't be undone",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(deploymentTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the targeted application",
+			},
+			"source_url": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "URL of the archive (.tar.gz or .zip) of the source code to deploy, e.g. the archive of a Git ref generated by GitHub or GitLab",
+			},
+			"git_ref": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Git reference (commit SHA, branch or tag) of the deployed source code, displayed in the deployments history",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the deployment (success, build-error, crashed-error, etc.)",
+			},
+			"image_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the built image in bytes",
+			},
+			"duration": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Duration of the deployment in seconds",
+			},
+		},
+	}
+}
+
+func resourceDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	params := &scalingo.DeploymentsCreateParams{
+		SourceURL: d.Get("source_url").(string),
+	}
+	if gitRef, _ := d.Get("git_ref").(string); gitRef != "" {
+		params.GitRef = &gitRef
+	}
+
+	deployment, err := client.DeploymentsCreate(ctx, appID, params)
+	if err != nil {
+		return diag.Errorf("create deployment: %v", err)
+	}
+	// The build doesn't prevent the other operations on the application,
+	// which mustn't wait for it.
+	unlock()
+	// The ID is set before waiting so that a failed deployment is tainted
+	// and deployed again by the next apply.
+	d.SetId(deployment.ID)
+
+	deployment, err = waitDeployment(ctx, client, appID, deployment, d.Timeout(schema.TimeoutCreate))
+	if deployment != nil {
+		if setErr := setDeployment(d, deployment); setErr != nil {
+			return diag.FromErr(setErr)
+		}
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDeploymentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+
+	deployment, err := client.Deployment(ctx, appID, d.Id())
+	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_deployment")
+			return nil
+		}
+		return diag.Errorf("get deployment: %v", err)
+	}
+
+	err = setDeployment(d, deployment)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceDeploymentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+func setDeployment(d *schema.ResourceData, deployment *scalingo.Deployment) error {
+	err := SetAll(d, map[string]interface{}{
+		"git_ref":    deployment.GitRef,
+		"status":     string(deployment.Status),
+		"image_size": int(deployment.ImageSize),
+		"duration":   deployment.Duration,
+	})
+	if err != nil {
+		return fmt.Errorf("store deployment information: %v", err)
+	}
+	return nil
+}
+
+// resourceDeploymentImport is called when importing a new deployment
+// resource. The ID must be "appID:deploymentID".
+//
+// The source URL is not returned by the API and is left empty: the next plan
+// triggers a new deployment unless the configuration ignores its changes.
+func resourceDeploymentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	appID, deploymentID, ok := strings.Cut(d.Id(), ":")
+	if !ok || appID == "" || deploymentID == "" {
+		return nil, fmt.Errorf("ID should have the following format: <app ID>:<deployment ID>")
+	}
+
+	client, _ := meta.(*providerMeta)
+	deployment, err := client.Deployment(ctx, appID, deploymentID)
+	if err != nil {
+		return nil, fmt.Errorf("get deployment: %v", err)
+	}
+
+	d.SetId(deployment.ID)
+	err = d.Set("app", appID)
+	if err != nil {
+		return nil, fmt.Errorf("store app: %v", err)
+	}
+	err = setDeployment(d, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
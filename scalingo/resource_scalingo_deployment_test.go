@@ -0,0 +1,167 @@
+package scalingo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceDeployment_Basic(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_deployment", meta, nil, map[string]any{
+		"app":        app.ID,
+		"source_url": "https://github.com/owner/repo/archive/v1.0.0.tar.gz",
+		"git_ref":    "v1.0.0",
+	})
+	if len(app.deployments) != 1 {
+		t.Fatalf("expected 1 deployment, got %d", len(app.deployments))
+	}
+	if state.ID != app.deployments[0].ID {
+		t.Errorf("expected the ID to be the deployment ID, got %v", state.ID)
+	}
+	assertAttr(t, state, "git_ref", "v1.0.0")
+	assertAttr(t, state, "status", "success")
+	assertAttr(t, state, "image_size", "123456789")
+	assertAttr(t, state, "duration", "42")
+
+	state = mustRefreshResource(t, "scalingo_deployment", meta, state)
+	assertAttr(t, state, "status", "success")
+
+	// Deploying another version replaces the resource.
+	diff, diags := planResource(t, "scalingo_deployment", meta, state, map[string]any{
+		"app":        app.ID,
+		"source_url": "https://github.com/owner/repo/archive/v1.1.0.tar.gz",
+		"git_ref":    "v1.1.0",
+	})
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if !diff.RequiresNew() {
+		t.Error("expected a new deployment")
+	}
+
+	mustDestroyResource(t, "scalingo_deployment", meta, state)
+	if len(app.deployments) != 1 {
+		t.Error("expected the deployment to be left untouched")
+	}
+}
+
+func TestResourceDeployment_Failure(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.deploymentStatus = scalingo.StatusBuildError
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state, diags := applyResource(t, "scalingo_deployment", meta, nil, map[string]any{
+		"app":        app.ID,
+		"source_url": "https://example.test/archive.tar.gz",
+	})
+	assertDiagContains(t, diags, "failed with status build-error, last lines of the build output:\n       Installing dependency 7\n")
+	assertDiagContains(t, diags, "Installing dependency 25\n !     Build failed: build-error")
+	for _, d := range diags {
+		if strings.Contains(d.Summary, "Installing dependency 6\n") {
+			t.Errorf("expected only the last lines of the output, got %v", d.Summary)
+		}
+	}
+
+	// The failed deployment is kept in the state to be tainted.
+	if state == nil || state.ID != app.deployments[0].ID {
+		t.Fatalf("expected the failed deployment to be stored, got %v", state)
+	}
+	assertAttr(t, state, "status", "build-error")
+}
+
+func TestResourceDeployment_Timeout(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.pendingPolls = 1000
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	_, diags := applyResource(t, "scalingo_deployment", meta, nil, map[string]any{
+		"app":        app.ID,
+		"source_url": "https://example.test/archive.tar.gz",
+		"timeouts":   map[string]any{"create": "50ms"},
+	})
+	assertDiagContains(t, diags, "waiting for the deployment "+app.deployments[0].ID+" (last status: building)")
+}
+
+func TestResourceDeployment_ReleasesLockDuringBuild(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	// The build lasts for about a second.
+	f.pendingPolls = 100
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, diags := applyResource(t, "scalingo_deployment", meta, nil, map[string]any{
+			"app":        app.ID,
+			"source_url": "https://example.test/archive.tar.gz",
+		})
+		if diags.HasError() {
+			t.Errorf("apply: %v", diags)
+		}
+	}()
+	for f.requestCount(http.MethodPost, "/v1/apps/"+app.ID+"/deployments") == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// The other operations on the application don't wait for the build.
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	unlock, err := meta.(*providerMeta).lockApp(ctx, app.ID)
+	if err != nil {
+		t.Fatalf("expected the lock of the application to be released during the build: %v", err)
+	}
+	unlock()
+	<-done
+}
+
+func TestResourceDeployment_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	deployment := f.createDeployment(app, scalingo.DeploymentsCreateParams{SourceURL: "https://example.test/archive.tar.gz"})
+
+	state := mustImportResource(t, "scalingo_deployment", meta, app.ID+":"+deployment.ID)
+	assertAttr(t, state, "app", app.ID)
+	if state.ID != deployment.ID {
+		t.Errorf("expected ID %v, got %v", deployment.ID, state.ID)
+	}
+
+	_, err := importResource(t, "scalingo_deployment", meta, deployment.ID)
+	if err == nil {
+		t.Error("expected an error for an import ID without application")
+	}
+}
+
+func TestFollowDeploymentStream(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	deployment := f.createDeployment(app, scalingo.DeploymentsCreateParams{SourceURL: "https://example.test/archive.tar.gz"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		followDeploymentStream(ctx, meta.(*providerMeta), app.ID, deployment.ID)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for f.requestCount("STREAM", "/apps/"+app.ID) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the deployment stream to be followed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the stream to be closed once the context is canceled")
+	}
+}
@@ -11,6 +11,11 @@ import (
 	"github.com/Scalingo/go-scalingo/v11"
 )
 
+// domainTimeout is the default timeout of the operations on a domain. They are
+// immediate but wait for the other operations on the application, such as a
+// deployment, to be done.
+const domainTimeout = deploymentTimeout
+
 func resourceScalingoDomain() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceDomainCreate,
@@ -21,6 +26,11 @@ func resourceScalingoDomain() *schema.Resource {
 			StateContext: resourceDomainImporter,
 		},
 		Description: "Resource representing a custom domain targeting an application",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(domainTimeout),
+			Update: schema.DefaultTimeout(domainTimeout),
+			Delete: schema.DefaultTimeout(domainTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"common_name": {
@@ -52,9 +62,16 @@ func resourceScalingoDomain() *schema.Resource {
 }
 
 func resourceDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	domainName, _ := d.Get("common_name").(string)
 	canonical, _ := d.Get("canonical").(bool)
 	letsEncryptEnabled, _ := d.Get("letsencrypt_enabled").(bool)
@@ -74,9 +91,16 @@ func resourceDomainCreate(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	canonical, _ := d.Get("canonical").(bool)
 
 	if d.HasChange("canonical") {
@@ -102,11 +126,15 @@ func resourceDomainUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 	domain, err := client.DomainsShow(ctx, appID, d.Id())
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_domain")
+			return nil
+		}
 		return diag.Errorf("fail to get domain: %v", err)
 	}
 
@@ -123,11 +151,17 @@ func resourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interf
 }
 
 func resourceDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 
-	err := client.DomainsRemove(ctx, appID, d.Id())
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	err = client.DomainsRemove(ctx, appID, d.Id())
 	if err != nil {
 		return diag.Errorf("fail to remove domain: %v", err)
 	}
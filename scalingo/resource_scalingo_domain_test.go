@@ -0,0 +1,76 @@
+package scalingo
+
+import (
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceDomain_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_domain", meta, nil, map[string]any{
+		"app":         app.ID,
+		"common_name": "www.example.test",
+	})
+	if len(app.domains) != 1 {
+		t.Fatalf("expected a domain to be added, got %d", len(app.domains))
+	}
+	domain := app.domains[0]
+	if state.ID != domain.ID {
+		t.Errorf("expected ID %s, got %s", domain.ID, state.ID)
+	}
+	if !domain.LetsEncryptEnabled {
+		t.Error("expected Let's Encrypt to be enabled by default")
+	}
+
+	state = mustRefreshResource(t, "scalingo_domain", meta, state)
+	assertAttr(t, state, "common_name", "www.example.test")
+	assertAttr(t, state, "canonical", "false")
+
+	state = mustApplyResource(t, "scalingo_domain", meta, state, map[string]any{
+		"app":         app.ID,
+		"common_name": "www.example.test",
+		"canonical":   true,
+	})
+	assertAttr(t, state, "canonical", "true")
+	if !domain.Canonical {
+		t.Error("expected the domain to be canonical")
+	}
+
+	state = mustApplyResource(t, "scalingo_domain", meta, state, map[string]any{
+		"app":         app.ID,
+		"common_name": "www.example.test",
+		"canonical":   false,
+	})
+	assertAttr(t, state, "canonical", "false")
+	if domain.Canonical {
+		t.Error("expected the domain not to be canonical anymore")
+	}
+
+	mustDestroyResource(t, "scalingo_domain", meta, state)
+	if len(app.domains) != 0 {
+		t.Error("domain has not been removed")
+	}
+}
+
+func TestResourceDomain_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	created := mustApplyResource(t, "scalingo_domain", meta, nil, map[string]any{
+		"app":         app.ID,
+		"common_name": "www.example.test",
+		"canonical":   true,
+	})
+
+	state := mustImportResource(t, "scalingo_domain", meta, app.ID+":"+created.ID)
+	assertAttr(t, state, "app", app.ID)
+	assertAttr(t, state, "common_name", "www.example.test")
+	assertAttr(t, state, "canonical", "true")
+
+	_, err := importResource(t, "scalingo_domain", meta, created.ID)
+	if err == nil {
+		t.Error("expected an error for an import ID without application")
+	}
+}
@@ -0,0 +1,195 @@
+package scalingo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceScalingoEnvironmentVariable() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceEnvironmentVariableCreate,
+		ReadContext:   resourceEnvironmentVariableRead,
+		UpdateContext: resourceEnvironmentVariableUpdate,
+		DeleteContext: resourceEnvironmentVariableDelete,
+		CustomizeDiff: resourceEnvironmentVariableCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceEnvironmentVariableImport,
+		},
+		Description: "Resource representing an environment variable of an application. " +
+			"A variable must not be managed both by this resource and by the environment of the `scalingo_app` resource",
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the targeted application",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the environment variable",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Value of the environment variable",
+			},
+		},
+	}
+}
+
+func resourceEnvironmentVariableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+	name, _ := d.Get("name").(string)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	variables, err := client.VariablesList(ctx, appID)
+	if err != nil {
+		return diag.Errorf("list application variables: %v", err)
+	}
+	if _, ok := variables.Contains(name); ok {
+		return diag.Errorf("environment variable %v already exists on application %v, import it to manage it with Terraform", name, appID)
+	}
+
+	_, err = client.VariableSet(ctx, appID, name, d.Get("value").(string))
+	if err != nil {
+		return diag.Errorf("set environment variable: %v", err)
+	}
+
+	d.SetId(appID + ":" + name)
+	client.variables.setManagedByResource(appID, name, true)
+
+	return nil
+}
+
+func resourceEnvironmentVariableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+	name, _ := d.Get("name").(string)
+
+	variables, err := client.VariablesList(ctx, appID)
+	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_environment_variable")
+			return nil
+		}
+		return diag.Errorf("list application variables: %v", err)
+	}
+
+	variable, ok := variables.Contains(name)
+	if !ok {
+		removeFromState(ctx, d, "scalingo_environment_variable")
+		return nil
+	}
+
+	err = d.Set("value", variable.Value)
+	if err != nil {
+		return diag.Errorf("store environment variable value: %v", err)
+	}
+	client.variables.setManagedByResource(appID, name, true)
+
+	return nil
+}
+
+func resourceEnvironmentVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	_, err = client.VariableSet(ctx, appID, d.Get("name").(string), d.Get("value").(string))
+	if err != nil {
+		return diag.Errorf("set environment variable: %v", err)
+	}
+
+	return nil
+}
+
+func resourceEnvironmentVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+	name, _ := d.Get("name").(string)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	client.variables.setManagedByResource(appID, name, false)
+	err = deleteVariablesByName(ctx, client, appID, []string{name})
+	if err != nil {
+		return diag.Errorf("unset environment variable: %v", err)
+	}
+
+	return nil
+}
+
+// resourceEnvironmentVariableCustomizeDiff refuses to manage a variable which
+// is also managed by the environment of the application resource: each
+// resource would overwrite or delete the value of the other one.
+func resourceEnvironmentVariableCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("app") || !d.NewValueKnown("name") {
+		return nil
+	}
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+	name, _ := d.Get("name").(string)
+	if client.variables.managedByApp(appID, name) {
+		return fmt.Errorf("environment variable %v is already managed by the environment of the scalingo_app resource of application %v, remove it from one of them", name, appID)
+	}
+	return nil
+}
+
+// resourceEnvironmentVariableImport is called when importing a new
+// environment variable resource. The ID must be "appID:NAME" such as
+// "5a155aa8f112e20010779b7a:SENTRY_DSN".
+func resourceEnvironmentVariableImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	appID, name, ok := strings.Cut(d.Id(), ":")
+	if !ok || appID == "" || name == "" {
+		return nil, fmt.Errorf("ID should have the following format: <app ID>:<variable name>")
+	}
+
+	client, _ := meta.(*providerMeta)
+	variables, err := client.VariablesList(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("list application variables: %v", err)
+	}
+	variable, ok := variables.Contains(name)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %v not found on application %v", name, appID)
+	}
+
+	err = SetAll(d, map[string]interface{}{
+		"app":   appID,
+		"name":  name,
+		"value": variable.Value,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("store environment variable information: %v", err)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
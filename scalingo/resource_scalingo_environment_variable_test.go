@@ -0,0 +1,207 @@
+package scalingo
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceEnvironmentVariable_Basic(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_environment_variable", meta, nil, map[string]any{
+		"app":   app.ID,
+		"name":  "SENTRY_DSN",
+		"value": "https://sentry.example.test/1",
+	})
+	if state.ID != app.ID+":SENTRY_DSN" {
+		t.Errorf("unexpected ID %v", state.ID)
+	}
+	variable, ok := app.variables.Contains("SENTRY_DSN")
+	if !ok || variable.Value != "https://sentry.example.test/1" {
+		t.Fatalf("expected the variable to be set, got %v", app.variables)
+	}
+
+	state = mustRefreshResource(t, "scalingo_environment_variable", meta, state)
+	assertAttr(t, state, "value", "https://sentry.example.test/1")
+
+	state = mustApplyResource(t, "scalingo_environment_variable", meta, state, map[string]any{
+		"app":   app.ID,
+		"name":  "SENTRY_DSN",
+		"value": "https://sentry.example.test/2",
+	})
+	variable, _ = app.variables.Contains("SENTRY_DSN")
+	if variable.Value != "https://sentry.example.test/2" {
+		t.Errorf("expected the variable to be updated, got %v", variable.Value)
+	}
+
+	mustDestroyResource(t, "scalingo_environment_variable", meta, state)
+	if len(app.variables) != 0 {
+		t.Errorf("expected the variable to be unset, got %v", app.variables)
+	}
+}
+
+func TestResourceEnvironmentVariable_AlreadyExists(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	app.setVariable(f, "SENTRY_DSN", "https://sentry.example.test/1")
+
+	_, diags := applyResource(t, "scalingo_environment_variable", meta, nil, map[string]any{
+		"app":   app.ID,
+		"name":  "SENTRY_DSN",
+		"value": "https://sentry.example.test/2",
+	})
+	assertDiagContains(t, diags, "environment variable SENTRY_DSN already exists")
+	variable, _ := app.variables.Contains("SENTRY_DSN")
+	if variable.Value != "https://sentry.example.test/1" {
+		t.Error("expected the existing variable to be left untouched")
+	}
+}
+
+func TestResourceEnvironmentVariable_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	app.setVariable(f, "FEATURE_FLAG", "true")
+
+	state := mustImportResource(t, "scalingo_environment_variable", meta, app.ID+":FEATURE_FLAG")
+	assertAttr(t, state, "app", app.ID)
+	assertAttr(t, state, "name", "FEATURE_FLAG")
+	assertAttr(t, state, "value", "true")
+
+	for _, id := range []string{"FEATURE_FLAG", app.ID + ":MISSING"} {
+		_, err := importResource(t, "scalingo_environment_variable", meta, id)
+		if err == nil {
+			t.Errorf("expected an error when importing %v", id)
+		}
+	}
+}
+
+func TestResourceEnvironmentVariable_ConflictWithApp(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	appState := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{
+		"name":        "my-app",
+		"environment": map[string]any{"FOO": "bar", "SENTRY_DSN": "https://sentry.example.test/1"},
+	})
+	app := f.findApp("my-app")
+
+	// The app resource is planned before the variables depending on it.
+	appConfig := map[string]any{
+		"name":        "my-app",
+		"environment": map[string]any{"FOO": "bar", "SENTRY_DSN": "https://sentry.example.test/1"},
+	}
+	_, diags := planResource(t, "scalingo_app", meta, appState, appConfig)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	_, diags = planResource(t, "scalingo_environment_variable", meta, nil, map[string]any{
+		"app":   app.ID,
+		"name":  "SENTRY_DSN",
+		"value": "https://sentry.example.test/2",
+	})
+	assertDiagContains(t, diags, "environment variable SENTRY_DSN is already managed by the environment of the scalingo_app resource")
+
+	// Moving the variable from the app to its own resource is allowed.
+	delete(appConfig["environment"].(map[string]any), "SENTRY_DSN")
+	_, diags = planResource(t, "scalingo_app", meta, appState, appConfig)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	_, diags = planResource(t, "scalingo_environment_variable", meta, nil, map[string]any{
+		"app":   app.ID,
+		"name":  "SENTRY_DSN",
+		"value": "https://sentry.example.test/2",
+	})
+	if diags.HasError() {
+		t.Errorf("expected the variable to be moved, got %v", diags)
+	}
+}
+
+// The resources are planned by Terraform itself, which plans the application
+// before the variables referencing it.
+func TestResourceEnvironmentVariable_ConflictWithAppWithTerraform(t *testing.T) {
+	f := newFakeAPI(t)
+	appConfig := testProviderConfig(f) + `
+resource "scalingo_app" "app" {
+  name = "my-app"
+  environment = {
+    FOO        = "bar"
+    SENTRY_DSN = "https://sentry.example.test/1"
+  }
+}
+`
+	variableConfig := `
+resource "scalingo_environment_variable" "sentry_dsn" {
+  app   = scalingo_app.app.id
+  name  = "SENTRY_DSN"
+  value = "https://sentry.example.test/2"
+}
+`
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testTerraformPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: appConfig,
+			},
+			{
+				Config:      appConfig + variableConfig,
+				ExpectError: regexp.MustCompile(`environment\s+variable\s+SENTRY_DSN\s+is\s+already\s+managed\s+by\s+the\s+environment`),
+			},
+			{
+				// Moving the variable from the app to its own resource is
+				// allowed.
+				Config: testProviderConfig(f) + `
+resource "scalingo_app" "app" {
+  name = "my-app"
+  environment = {
+    FOO = "bar"
+  }
+}
+` + variableConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("scalingo_environment_variable.sentry_dsn", "value", "https://sentry.example.test/2"),
+					func(*terraform.State) error {
+						unlock := f.lock()
+						defer unlock()
+						variable, ok := f.findApp("my-app").variables.Contains("SENTRY_DSN")
+						if !ok || variable.Value != "https://sentry.example.test/2" {
+							return errors.New("expected the variable to be set by its own resource")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestResourceEnvironmentVariable_NotDeletedByApp(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	appState := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{
+		"name":        "my-app",
+		"environment": map[string]any{"FOO": "bar", "SENTRY_DSN": "https://sentry.example.test/1"},
+	})
+	app := f.findApp("my-app")
+
+	// The variable is now managed by its own resource but the app still
+	// has it in its state.
+	variableState := mustImportResource(t, "scalingo_environment_variable", meta, app.ID+":SENTRY_DSN")
+	mustRefreshResource(t, "scalingo_environment_variable", meta, variableState)
+
+	mustApplyResource(t, "scalingo_app", meta, appState, map[string]any{
+		"name":        "my-app",
+		"environment": map[string]any{"FOO": "bar"},
+	})
+	if _, ok := app.variables.Contains("SENTRY_DSN"); !ok {
+		t.Error("expected the variable managed by its own resource not to be deleted by the app")
+	}
+}
@@ -84,10 +84,16 @@ func resourceScalingoLogDrain() *schema.Resource {
 }
 
 func resourceLogDrainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	logDrainType, _ := d.Get("type").(string)
 	host, _ := d.Get("host").(string)
 	port, _ := d.Get("port").(string)
@@ -105,7 +111,6 @@ func resourceLogDrainCreate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	var res *scalingo.LogDrainRes
-	var err error
 
 	addonID, ok := d.Get("addon").(string)
 	if ok && addonID != "" {
@@ -128,7 +133,7 @@ func resourceLogDrainCreate(ctx context.Context, d *schema.ResourceData, meta in
 }
 
 func resourceLogDrainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
 	drainURL, _ := d.Get("drain_url").(string)
@@ -147,6 +152,10 @@ func resourceLogDrainRead(ctx context.Context, d *schema.ResourceData, meta inte
 		res, err = client.LogDrainsList(ctx, appID)
 	}
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_log_drain")
+			return nil
+		}
 		return diag.Errorf("fail to list log drains: %v", err)
 	}
 
@@ -160,23 +169,29 @@ func resourceLogDrainRead(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
-	if logDrain == nil || drainURL != logDrain.URL {
-		d.MarkNewResource()
+	if logDrain == nil {
+		removeFromState(ctx, d, "scalingo_log_drain")
 	}
 	return nil
 }
 
 func resourceLogDrainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	appID, _ := d.Get("app").(string)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	drainURL, _ := d.Get("drain_url").(string)
 
 	if drainURL == "" {
 		return diag.Errorf("no drain_url set")
 	}
 
-	var err error
 	addonID, ok := d.Get("addon").(string)
 	if ok && addonID != "" {
 		err = client.LogDrainAddonRemove(ctx, appID, addonID, drainURL)
@@ -0,0 +1,76 @@
+package scalingo
+
+import (
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceLogDrain_App(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_log_drain", meta, nil, map[string]any{
+		"app":  app.ID,
+		"type": "syslog",
+		"host": "logs.example.test",
+		"port": "514",
+	})
+	if len(app.logDrains) != 1 {
+		t.Fatalf("expected a log drain to be added, got %d", len(app.logDrains))
+	}
+	assertAttr(t, state, "drain_url", app.logDrains[0].URL)
+
+	state = mustRefreshResource(t, "scalingo_log_drain", meta, state)
+	assertAttr(t, state, "drain_url", "syslog://logs.example.test:514")
+
+	mustDestroyResource(t, "scalingo_log_drain", meta, state)
+	if len(app.logDrains) != 0 {
+		t.Error("log drain has not been removed")
+	}
+}
+
+func TestResourceLogDrain_Addon(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	provider := f.findAddonProvider("postgresql")
+	addon := f.provisionAddon(app, provider, findPlan(provider, "pl-pg-512"))
+
+	state := mustApplyResource(t, "scalingo_log_drain", meta, nil, map[string]any{
+		"app":   app.ID,
+		"addon": addon.ID,
+		"type":  "elk",
+		"url":   "https://elk.example.test",
+	})
+	if len(addon.logDrains) != 1 || len(app.logDrains) != 0 {
+		t.Fatalf("expected a log drain to be added on the addon only")
+	}
+	assertAttr(t, state, "drain_url", addon.logDrains[0].URL)
+
+	mustDestroyResource(t, "scalingo_log_drain", meta, state)
+	if len(addon.logDrains) != 0 {
+		t.Error("log drain has not been removed")
+	}
+}
+
+func TestResourceLogDrain_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	created := mustApplyResource(t, "scalingo_log_drain", meta, nil, map[string]any{
+		"app":  app.ID,
+		"type": "syslog",
+		"host": "logs.example.test",
+		"port": "514",
+	})
+
+	state := mustImportResource(t, "scalingo_log_drain", meta, app.ID+"#"+created.Attributes["drain_url"])
+	if state.ID != created.ID {
+		t.Errorf("expected ID %s, got %s", created.ID, state.ID)
+	}
+	assertAttr(t, state, "app", app.ID)
+
+	_, err := importResource(t, "scalingo_log_drain", meta, app.ID)
+	if err == nil {
+		t.Error("expected an error for an import ID without drain URL")
+	}
+}
@@ -91,10 +91,14 @@ func resourceScalingoNotifier() *schema.Resource {
 
 // resourceScNotifierRead performs the Scalingo API lookup
 func resourceScNotifierRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 	app, _ := d.Get("app").(string)
 	notifier, err := client.NotifierByID(ctx, app, d.Id())
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_notifier")
+			return nil
+		}
 		return diag.Errorf("fail to find notifier %v of app %v: %v", app, d.Id(), err)
 	}
 	err = setFromScNotifier(ctx, d, client, notifier)
@@ -106,7 +110,14 @@ func resourceScNotifierRead(ctx context.Context, d *schema.ResourceData, meta in
 
 // resourceScNotifierCreate creates a notifier calling the Scalingo API
 func resourceScNotifierCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, d.Get("app").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	params, err := readNotifierParamsFromResource(ctx, d, client)
 	if err != nil {
 		return diag.Errorf("fail to read notifier params from resource: %v", err)
@@ -124,7 +135,14 @@ func resourceScNotifierCreate(ctx context.Context, d *schema.ResourceData, meta
 
 // resourceScNotifierUpdate updates a notifier calling the Scalingo API
 func resourceScNotifierUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, d.Get("app").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	params, err := readNotifierParamsFromResource(ctx, d, client)
 	if err != nil {
 		return diag.Errorf("fail to read notifier params from resource: %v", err)
@@ -141,8 +159,15 @@ func resourceScNotifierUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceScNotifierDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
-	err := client.NotifierDestroy(ctx, d.Get("app").(string), d.Id())
+	client, _ := meta.(*providerMeta)
+
+	unlock, err := client.lockApp(ctx, d.Get("app").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	err = client.NotifierDestroy(ctx, d.Get("app").(string), d.Id())
 	if err != nil {
 		return diag.Errorf("fail to delete notifier: %v", err)
 	}
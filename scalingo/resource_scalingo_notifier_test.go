@@ -0,0 +1,75 @@
+package scalingo
+
+import (
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceNotifier_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_notifier", meta, nil, map[string]any{
+		"app":             app.ID,
+		"name":            "my-webhook",
+		"platform_id":     "np-webhook",
+		"webhook_url":     "https://hooks.example.test",
+		"selected_events": []any{"deployment"},
+	})
+	if len(app.notifiers) != 1 {
+		t.Fatalf("expected a notifier to be provisioned, got %d", len(app.notifiers))
+	}
+	notifier := app.notifiers[0]
+	if state.ID != notifier.ID {
+		t.Errorf("expected ID %s, got %s", notifier.ID, state.ID)
+	}
+	if len(notifier.SelectedEventIDs) != 1 || notifier.SelectedEventIDs[0] != "et-deployment" {
+		t.Errorf("expected the event names to be sent as IDs, got %v", notifier.SelectedEventIDs)
+	}
+	assertAttr(t, state, "type", "webhook")
+	assertAttr(t, state, "active", "true")
+
+	state = mustRefreshResource(t, "scalingo_notifier", meta, state)
+	assertAttr(t, state, "name", "my-webhook")
+	assertAttr(t, state, "webhook_url", "https://hooks.example.test")
+	assertAttr(t, state, "selected_events.#", "1")
+
+	state = mustApplyResource(t, "scalingo_notifier", meta, state, map[string]any{
+		"app":             app.ID,
+		"name":            "my-renamed-webhook",
+		"platform_id":     "np-webhook",
+		"webhook_url":     "https://hooks.example.test",
+		"active":          false,
+		"send_all_events": true,
+	})
+	assertAttr(t, state, "name", "my-renamed-webhook")
+	assertAttr(t, state, "active", "false")
+	assertAttr(t, state, "send_all_events", "true")
+
+	mustDestroyResource(t, "scalingo_notifier", meta, state)
+	if len(app.notifiers) != 0 {
+		t.Error("notifier has not been destroyed")
+	}
+}
+
+func TestResourceNotifier_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	created := mustApplyResource(t, "scalingo_notifier", meta, nil, map[string]any{
+		"app":         app.ID,
+		"name":        "my-email",
+		"platform_id": "np-email",
+		"emails":      []any{"ops@example.test"},
+	})
+
+	state := mustImportResource(t, "scalingo_notifier", meta, app.ID+":"+created.ID)
+	assertAttr(t, state, "app", app.ID)
+	assertAttr(t, state, "type", "email")
+	assertAttr(t, state, "emails.0", "ops@example.test")
+
+	_, err := importResource(t, "scalingo_notifier", meta, created.ID)
+	if err == nil {
+		t.Error("expected an error for an import ID without application")
+	}
+}
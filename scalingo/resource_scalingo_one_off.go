@@ -0,0 +1,257 @@
+package scalingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+// oneOffTimeout is the default timeout of a one-off container, from its
+// start to the end of its command.
+const oneOffTimeout = 30 * time.Minute
+
+// Statuses of the command of a one-off resource.
+const (
+	oneOffStatusRunning   = "running"
+	oneOffStatusSucceeded = "succeeded"
+	oneOffStatusFailed    = "failed"
+)
+
+func resourceScalingoOneOff() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceOneOffCreate,
+		ReadContext:   resourceOneOffRead,
+		DeleteContext: resourceOneOffDelete,
+		CustomizeDiff: resourceOneOffCustomizeDiff,
+		Description: "Resource running a command in a one-off container of an application, such as a database migration. " +
+			"Changing any argument, e.g. `triggers`, runs the command again. " +
+			"The API doesn't expose the exit status of the command: the apply fails with the last lines of its output if the one-off container is seen crashed, " +
+			"but the detection of a non-zero exit is only best-effort, a container exiting and removed between two checks is considered successful. " +
+			"The apply also fails if the container is never seen running, its command is then run again by the next apply. " +
+			"Destroying the resource only removes it from the state",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(oneOffTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"app": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the targeted application",
+			},
+			"command": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Command to run, e.g. `bundle exec rails db:migrate`",
+			},
+			"environment": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Sensitive:   true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Key-value map of environment variables added to the ones of the application for this command",
+			},
+			"size": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Size of the one-off container (S/M/L/etc.), M if unset",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary key-value map whose changes run the command again, e.g. the plan of a database or the version of the deployed code",
+			},
+			"container_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the one-off container, to look for its output in the logs of the application",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the command: running, succeeded, or failed if its container has been seen crashed",
+			},
+		},
+	}
+}
+
+func resourceOneOffCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client, _ := meta.(*providerMeta)
+
+	appID, _ := d.Get("app").(string)
+	command, _ := d.Get("command").(string)
+
+	unlock, err := client.lockApp(ctx, appID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	env := map[string]string{}
+	for name, value := range d.Get("environment").(map[string]interface{}) {
+		env[name], _ = value.(string)
+	}
+
+	res, err := client.Run(ctx, scalingo.RunOpts{
+		App:      appID,
+		Command:  []string{command},
+		Env:      env,
+		Size:     d.Get("size").(string),
+		Detached: true,
+	})
+	if err != nil {
+		return diag.Errorf("run one-off container: %v", err)
+	}
+	if res.Container == nil {
+		return diag.Errorf("run one-off container: no container returned by the API")
+	}
+	container := res.Container
+	containerName := fmt.Sprintf("%v-%d", container.Type, container.TypeIndex)
+
+	// The ID is set before waiting so that a failed command is tainted and run
+	// again by the next apply.
+	d.SetId(container.ID)
+	err = SetAll(d, map[string]interface{}{
+		"container_name": containerName,
+		"status":         oneOffStatusRunning,
+	})
+	if err != nil {
+		return diag.Errorf("store one-off information: %v", err)
+	}
+
+	timeout := d.Timeout(schema.TimeoutCreate)
+	if res.OperationURL != "" {
+		err = waitOperation(ctx, client, res.OperationURL, fmt.Sprintf("the start of the one-off container %v", containerName), timeout)
+		if err != nil {
+			return diag.Errorf("wait for the one-off container to start: %v", err)
+		}
+	}
+
+	err = waitOneOff(ctx, client, appID, container.ID, timeout)
+	var failedErr *oneOffFailedError
+	if errors.As(err, &failedErr) {
+		if setErr := d.Set("status", oneOffStatusFailed); setErr != nil {
+			return diag.Errorf("store one-off status: %v", setErr)
+		}
+		return diag.Errorf("command %q failed: %v%s", command, err, oneOffOutputTail(ctx, client, appID, containerName))
+	}
+	if err != nil {
+		return diag.Errorf("wait for the command %q to be finished: %v", command, err)
+	}
+
+	err = d.Set("status", oneOffStatusSucceeded)
+	if err != nil {
+		return diag.Errorf("store one-off status: %v", err)
+	}
+	tflog.Info(ctx, fmt.Sprintf("Command '%s' run in the one-off container %s of application %s", command, containerName, appID))
+
+	return nil
+}
+
+// resourceOneOffRead doesn't refresh anything: the one-off container doesn't
+// exist anymore once its command is finished.
+func resourceOneOffRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceOneOffDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceOneOffCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !shouldValidate(d, "size") {
+		return nil
+	}
+	client, _ := meta.(*providerMeta)
+
+	return validateContainerSize(ctx, client, d.Get("size").(string))
+}
+
+// oneOffFailedError is returned by waitOneOff when the command of the one-off
+// container exited with a non-zero status.
+type oneOffFailedError struct {
+	containerName string
+}
+
+func (err *oneOffFailedError) Error() string {
+	return fmt.Sprintf("the one-off container %v exited with a non-zero status", err.containerName)
+}
+
+// waitOneOff waits for the command of the one-off container to be finished.
+// The API doesn't expose the exit code of the command: the container
+// disappears from the containers of the application when the command is
+// finished, and is reported as crashed for a while when it failed. A container
+// never listed may have failed, it is reported as an error.
+func waitOneOff(ctx context.Context, client *providerMeta, appID, containerID string, timeout time.Duration) error {
+	state := ""
+	seen := false
+	return waitUntil(ctx, waitOptions{
+		timeout:   timeout,
+		operation: "the end of the one-off command",
+		status: func() string {
+			return state
+		},
+	}, func() (bool, error) {
+		containers, err := client.AppsContainersPs(ctx, appID)
+		if err != nil {
+			return false, fmt.Errorf("list containers: %w", err)
+		}
+		for _, container := range containers {
+			if container.ID != containerID {
+				continue
+			}
+			seen = true
+			state = container.State
+			if state == containerStateCrashed {
+				return false, &oneOffFailedError{containerName: fmt.Sprintf("%v-%d", container.Type, container.TypeIndex)}
+			}
+			return false, nil
+		}
+		if !seen {
+			return false, errors.New("the one-off container has never been listed, the exit status of its command is unknown")
+		}
+		return true, nil
+	})
+}
+
+// oneOffOutputTail returns the last lines of the output of the one-off
+// container, formatted to be appended to an error message. It is empty if
+// the output can't be fetched.
+func oneOffOutputTail(ctx context.Context, client *providerMeta, appID, containerName string) string {
+	logsURL, err := client.LogsURL(ctx, appID)
+	if err != nil {
+		tflog.Warn(ctx, "Fail to get the logs URL of the application", map[string]any{"app": appID, "error": err.Error()})
+		return ""
+	}
+
+	body, err := client.Logs(ctx, logsURL.LogsURL, outputTailLines, containerName)
+	if err != nil {
+		if !errors.Is(err, scalingo.ErrNoLogs) {
+			tflog.Warn(ctx, "Fail to get the output of the one-off container", map[string]any{"container": containerName, "error": err.Error()})
+		}
+		return ""
+	}
+	defer body.Close()
+
+	output, err := io.ReadAll(body)
+	if err != nil {
+		tflog.Warn(ctx, "Fail to read the output of the one-off container", map[string]any{"container": containerName, "error": err.Error()})
+		return ""
+	}
+
+	return formatOutputTail("output", output)
+}
@@ -0,0 +1,122 @@
+package scalingo
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceOneOff_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.pendingPolls = 2
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	config := map[string]any{
+		"app":         app.ID,
+		"command":     "bundle exec rails db:migrate",
+		"environment": map[string]any{"VERBOSE": "true"},
+		"size":        "L",
+		"triggers":    map[string]any{"plan": "postgresql-starter-512"},
+	}
+	state := mustApplyResource(t, "scalingo_one_off", meta, nil, config)
+	if len(app.oneOffs) != 1 {
+		t.Fatalf("expected a one-off container to be run, got %d", len(app.oneOffs))
+	}
+	oneOff := app.oneOffs[0]
+	if oneOff.Command != "bundle exec rails db:migrate" || oneOff.env["VERBOSE"] != "true" || oneOff.ContainerSize.Name != "L" {
+		t.Errorf("expected the one-off container to run the command with its environment and size, got %+v", oneOff)
+	}
+	if state.ID != oneOff.ID {
+		t.Errorf("expected ID %s, got %s", oneOff.ID, state.ID)
+	}
+	assertAttr(t, state, "container_name", "one-off-1")
+	assertAttr(t, state, "status", "succeeded")
+	if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID+"/ps"); count != 3 {
+		t.Errorf("expected the containers to be listed until the end of the command, got %d requests", count)
+	}
+
+	state = mustRefreshResource(t, "scalingo_one_off", meta, state)
+	assertAttr(t, state, "status", "succeeded")
+	diff, diags := planResource(t, "scalingo_one_off", meta, state, config)
+	if diags.HasError() {
+		t.Fatalf("plan: %v", diags)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected an empty plan, got %v", diff)
+	}
+
+	// Changing the triggers runs the command again.
+	config["triggers"] = map[string]any{"plan": "postgresql-starter-1024"}
+	state = mustApplyResource(t, "scalingo_one_off", meta, state, config)
+	if len(app.oneOffs) != 2 {
+		t.Fatalf("expected the command to be run again, got %d one-off containers", len(app.oneOffs))
+	}
+	assertAttr(t, state, "container_name", "one-off-2")
+
+	mustDestroyResource(t, "scalingo_one_off", meta, state)
+}
+
+func TestResourceOneOff_FailedCommand(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.oneOffCrash = true
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state, diags := applyResource(t, "scalingo_one_off", meta, nil, map[string]any{
+		"app": app.ID, "command": "rake db:seed",
+	})
+	assertDiagContains(t, diags, `command "rake db:seed" failed: the one-off container one-off-1 exited with a non-zero status, last lines of the output:`)
+	assertDiagContains(t, diags, "output line 25")
+	for _, d := range diags {
+		if strings.Contains(d.Summary, "output line 5\n") {
+			t.Errorf("expected only the last lines of the output, got %v", d.Summary)
+		}
+	}
+
+	// The resource is tainted so that the command is run again.
+	if state == nil || state.ID == "" {
+		t.Fatal("expected the failed command to be kept in the state")
+	}
+	assertAttr(t, state, "status", "failed")
+}
+
+func TestResourceOneOff_NeverListed(t *testing.T) {
+	f, meta := newTestProvider(t)
+	// The command is finished before the containers are listed.
+	f.pendingPolls = 0
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state, diags := applyResource(t, "scalingo_one_off", meta, nil, map[string]any{
+		"app": app.ID, "command": "rake db:migrate",
+	})
+	assertDiagContains(t, diags, "the one-off container has never been listed, the exit status of its command is unknown")
+	if state == nil || state.ID == "" {
+		t.Fatal("expected the command to be kept in the state to be run again")
+	}
+	assertAttr(t, state, "status", "running")
+}
+
+func TestResourceOneOff_WaitsForStartOperation(t *testing.T) {
+	f, meta := newTestProvider(t)
+	f.operationError = "no capacity available"
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	_, diags := applyResource(t, "scalingo_one_off", meta, nil, map[string]any{
+		"app": app.ID, "command": "rake db:seed",
+	})
+	assertDiagContains(t, diags, "the start of the one-off container one-off-1 failed: no capacity available")
+}
+
+func TestResourceOneOff_InvalidSize(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	_, diags := applyResource(t, "scalingo_one_off", meta, nil, map[string]any{
+		"app": app.ID, "command": "rake db:seed", "size": "XXL",
+	})
+	assertDiagContains(t, diags, `"XXL" is not a valid container size`)
+	if len(app.oneOffs) != 0 {
+		t.Errorf("expected no one-off container to be run, got %d", len(app.oneOffs))
+	}
+}
@@ -0,0 +1,44 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestResourceProject_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	state := mustApplyResource(t, "scalingo_project", meta, nil, map[string]any{
+		"name": "my-project",
+	})
+	project := f.findProject(state.ID)
+	if project == nil {
+		t.Fatal("project has not been created")
+	}
+	assertAttr(t, state, "default", "false")
+
+	state = mustRefreshResource(t, "scalingo_project", meta, state)
+	assertAttr(t, state, "name", "my-project")
+
+	state = mustApplyResource(t, "scalingo_project", meta, state, map[string]any{
+		"name": "my-renamed-project",
+	})
+	assertAttr(t, state, "name", "my-renamed-project")
+	if project.Name != "my-renamed-project" {
+		t.Errorf("expected the project to be renamed, got %s", project.Name)
+	}
+
+	mustDestroyResource(t, "scalingo_project", meta, state)
+	if f.findProject(state.ID) != nil {
+		t.Error("project has not been deleted")
+	}
+}
+
+func TestResourceProject_Import(t *testing.T) {
+	_, meta := newTestProvider(t)
+	created := mustApplyResource(t, "scalingo_project", meta, nil, map[string]any{
+		"name": "my-project",
+	})
+
+	state := mustImportResource(t, "scalingo_project", meta, created.ID)
+	assertAttr(t, state, "name", "my-project")
+}
@@ -71,7 +71,7 @@ func resourceScalingoScmIntegration() *schema.Resource {
 }
 
 func resourceScmIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	scmType, _ := d.Get("scm_type").(string)
 	url, _ := d.Get("url").(string)
@@ -87,11 +87,15 @@ func resourceScmIntegrationCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceScmIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	id := d.Id()
 	integration, err := client.SCMIntegrationsShow(ctx, id)
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_scm_integration")
+			return nil
+		}
 		return diag.Errorf("fail to fetch scm integration: %v", err)
 	}
 	err = SetAll(d, map[string]interface{}{
@@ -112,7 +116,7 @@ func resourceScmIntegrationRead(ctx context.Context, d *schema.ResourceData, met
 }
 
 func resourceScmIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	id := d.Id()
 	err := client.SCMIntegrationsDelete(ctx, id)
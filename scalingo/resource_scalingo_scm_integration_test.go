@@ -0,0 +1,44 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+func TestResourceScmIntegration_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	state := mustApplyResource(t, "scalingo_scm_integration", meta, nil, map[string]any{
+		"scm_type":     "gitlab-self-hosted",
+		"url":          "https://gitlab.example.test",
+		"access_token": "glpat-secret",
+	})
+	if len(f.scmIntegrations) != 1 {
+		t.Fatalf("expected an SCM integration to be created, got %d", len(f.scmIntegrations))
+	}
+	if state.ID != f.scmIntegrations[0].ID {
+		t.Errorf("expected ID %s, got %s", f.scmIntegrations[0].ID, state.ID)
+	}
+
+	state = mustRefreshResource(t, "scalingo_scm_integration", meta, state)
+	assertAttr(t, state, "username", "fake-scm-user")
+	assertAttr(t, state, "email", "scm@fake.test")
+	assertAttr(t, state, "profile_url", "https://gitlab.example.test/fake-scm-user")
+
+	mustDestroyResource(t, "scalingo_scm_integration", meta, state)
+	if len(f.scmIntegrations) != 0 {
+		t.Error("SCM integration has not been deleted")
+	}
+}
+
+func TestResourceScmIntegration_Import(t *testing.T) {
+	_, meta := newTestProvider(t)
+	created := mustApplyResource(t, "scalingo_scm_integration", meta, nil, map[string]any{
+		"scm_type":     "gitlab-self-hosted",
+		"url":          "https://gitlab.example.test",
+		"access_token": "glpat-secret",
+	})
+
+	state := mustImportResource(t, "scalingo_scm_integration", meta, created.ID)
+	assertAttr(t, state, "scm_type", "gitlab-self-hosted")
+	assertAttr(t, state, "url", "https://gitlab.example.test")
+}
@@ -90,9 +90,16 @@ func resourceScalingoScmRepoLink() *schema.Resource {
 }
 
 func resourceScmRepoLinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	app, _ := d.Get("app").(string)
+
+	unlock, err := client.lockApp(ctx, app)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	source, _ := d.Get("source").(string)
 	branch, _ := d.Get("branch").(string)
 	autoDeployEnabled, _ := d.Get("auto_deploy_enabled").(bool)
@@ -146,10 +153,16 @@ func resourceScmRepoLinkCreate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceScmRepoLinkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	app, _ := d.Get("app").(string)
 
+	unlock, err := client.lockApp(ctx, app)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
 	changed := false
 	branch, _ := d.Get("branch").(string)
 	autoDeployEnabled, _ := d.Get("auto_deploy_enabled").(bool)
@@ -222,11 +235,15 @@ func resourceScmRepoLinkUpdate(ctx context.Context, d *schema.ResourceData, meta
 }
 
 func resourceScmRepoLinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 	app, _ := d.Get("app").(string)
 
 	link, err := client.SCMRepoLinkShow(ctx, app)
 	if err != nil {
+		if isNotFoundError(err) {
+			removeFromState(ctx, d, "scalingo_scm_repo_link")
+			return nil
+		}
 		return diag.FromErr(err)
 	}
 	d.SetId(link.AppID)
@@ -252,10 +269,16 @@ func resourceScmRepoLinkRead(ctx context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceScmRepoLinkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 	app, _ := d.Get("app").(string)
 
-	err := client.SCMRepoLinkDelete(ctx, app)
+	unlock, err := client.lockApp(ctx, app)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer unlock()
+
+	err = client.SCMRepoLinkDelete(ctx, app)
 	if err != nil {
 		return diag.Errorf("fail to delete scm repo link: %v", err)
 	}
@@ -0,0 +1,66 @@
+package scalingo
+
+import (
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestResourceScmRepoLink_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	state := mustApplyResource(t, "scalingo_scm_repo_link", meta, nil, map[string]any{
+		"app":                   app.ID,
+		"auth_integration_uuid": "scm-1",
+		"source":                "https://github.com/owner/repo",
+		"branch":                "main",
+		"auto_deploy_enabled":   true,
+	})
+	if app.scmRepoLink == nil {
+		t.Fatal("SCM repo link has not been created")
+	}
+	if state.ID != app.ID {
+		t.Errorf("expected ID %s, got %s", app.ID, state.ID)
+	}
+	if app.scmRepoLink.Owner != "owner" || app.scmRepoLink.Repo != "repo" || !app.scmRepoLink.AutoDeployEnabled {
+		t.Errorf("unexpected SCM repo link: %+v", app.scmRepoLink)
+	}
+
+	state = mustRefreshResource(t, "scalingo_scm_repo_link", meta, state)
+	assertAttr(t, state, "source", "https://github.com/owner/repo")
+	assertAttr(t, state, "branch", "main")
+
+	state = mustApplyResource(t, "scalingo_scm_repo_link", meta, state, map[string]any{
+		"app":                        app.ID,
+		"auth_integration_uuid":      "scm-1",
+		"source":                     "https://github.com/owner/repo",
+		"branch":                     "production",
+		"deploy_review_apps_enabled": true,
+	})
+	assertAttr(t, state, "branch", "production")
+	if app.scmRepoLink.Branch != "production" || !app.scmRepoLink.DeployReviewAppsEnabled || app.scmRepoLink.AutoDeployEnabled {
+		t.Errorf("SCM repo link has not been updated: %+v", app.scmRepoLink)
+	}
+
+	mustDestroyResource(t, "scalingo_scm_repo_link", meta, state)
+	if app.scmRepoLink != nil {
+		t.Error("SCM repo link has not been deleted")
+	}
+}
+
+func TestResourceScmRepoLink_Import(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	mustApplyResource(t, "scalingo_scm_repo_link", meta, nil, map[string]any{
+		"app":                   app.ID,
+		"auth_integration_uuid": "scm-1",
+		"source":                "https://github.com/owner/repo",
+		"branch":                "main",
+	})
+
+	state := mustImportResource(t, "scalingo_scm_repo_link", meta, app.ID)
+	assertAttr(t, state, "app", app.ID)
+	assertAttr(t, state, "auth_integration_uuid", "scm-1")
+	assertAttr(t, state, "source", "https://github.com/owner/repo")
+}
@@ -39,7 +39,7 @@ func resourceScalingoSSHKey() *schema.Resource {
 }
 
 func resourceSSHKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	keyName, _ := d.Get("key_name").(string)
 	keyContent, _ := d.Get("public_key").(string)
@@ -54,7 +54,7 @@ func resourceSSHKeyCreate(ctx context.Context, d *schema.ResourceData, meta inte
 }
 
 func resourceSSHKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	keyID := d.Id()
 
@@ -66,6 +66,10 @@ func resourceSSHKeyRead(ctx context.Context, d *schema.ResourceData, meta interf
 		return k.ID == keyID
 	})
 
+	if len(filteredKeys) == 0 {
+		removeFromState(ctx, d, "scalingo_ssh_key")
+		return nil
+	}
 	if len(filteredKeys) != 1 {
 		return diag.Errorf("fail to find the selected ssh key")
 	}
@@ -84,7 +88,7 @@ func resourceSSHKeyRead(ctx context.Context, d *schema.ResourceData, meta interf
 }
 
 func resourceSSHKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client, _ := meta.(*scalingo.Client)
+	client, _ := meta.(*providerMeta)
 
 	keyID := d.Id()
 
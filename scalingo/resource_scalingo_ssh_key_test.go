@@ -0,0 +1,47 @@
+package scalingo
+
+import (
+	"testing"
+)
+
+const testPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIFakeKeyForTests user@fake.test"
+
+func TestResourceSSHKey_Lifecycle(t *testing.T) {
+	f, meta := newTestProvider(t)
+
+	state := mustApplyResource(t, "scalingo_ssh_key", meta, nil, map[string]any{
+		"key_name":   "laptop",
+		"public_key": testPublicKey,
+	})
+	if len(f.keys) != 1 {
+		t.Fatalf("expected an SSH key to be added, got %d", len(f.keys))
+	}
+	if state.ID != f.keys[0].ID {
+		t.Errorf("expected ID %s, got %s", f.keys[0].ID, state.ID)
+	}
+
+	state = mustRefreshResource(t, "scalingo_ssh_key", meta, state)
+	assertAttr(t, state, "key_name", "laptop")
+	assertAttr(t, state, "public_key", testPublicKey)
+
+	mustDestroyResource(t, "scalingo_ssh_key", meta, state)
+	if len(f.keys) != 0 {
+		t.Error("SSH key has not been deleted")
+	}
+}
+
+func TestResourceSSHKey_Import(t *testing.T) {
+	_, meta := newTestProvider(t)
+	created := mustApplyResource(t, "scalingo_ssh_key", meta, nil, map[string]any{
+		"key_name":   "laptop",
+		"public_key": testPublicKey,
+	})
+
+	state := mustImportResource(t, "scalingo_ssh_key", meta, created.ID)
+	assertAttr(t, state, "key_name", "laptop")
+
+	_, err := importResource(t, "scalingo_ssh_key", meta, "unknown")
+	if err == nil {
+		t.Error("expected an error when importing an unknown key")
+	}
+}
@@ -3,6 +3,7 @@ package scalingo
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -41,6 +42,36 @@ func SetAll(d *schema.ResourceData, values map[string]interface{}) error {
 	return nil
 }
 
+// mergeSchemas returns a schema made of the attributes of all the given
+// schemas.
+func mergeSchemas(schemas ...map[string]*schema.Schema) map[string]*schema.Schema {
+	merged := map[string]*schema.Schema{}
+	for _, s := range schemas {
+		for key, attribute := range s {
+			merged[key] = attribute
+		}
+	}
+	return merged
+}
+
+// outputTailLines is the number of lines of an output reported when a
+// deployment or a one-off command fails.
+const outputTailLines = 20
+
+// formatOutputTail returns the last lines of the output, formatted to be
+// appended to an error message. It is empty if there is no output.
+func formatOutputTail(name string, output []byte) string {
+	trimmed := strings.TrimRight(string(output), "\n")
+	if trimmed == "" {
+		return ""
+	}
+	lines := strings.Split(trimmed, "\n")
+	if len(lines) > outputTailLines {
+		lines = lines[len(lines)-outputTailLines:]
+	}
+	return ", last lines of the " + name + ":\n" + strings.Join(lines, "\n")
+}
+
 func DiagnosticError(diagnostics diag.Diagnostics) error {
 	if len(diagnostics) == 0 {
 		return nil
@@ -71,18 +102,18 @@ func durationDiffSuppressFunc(k, oldValue, newValue string, d *schema.ResourceDa
 // getDBAPIContext resolves the appID and addonID needed for Database API calls
 // from a database ID. The database ID is stored in terraform state and differs
 // from the app ID.
-func getDBAPIContext(ctx context.Context, client *scalingo.Client, databaseID string) (string, string, error) {
-	previewClient := scalingo.NewPreviewClient(client)
+func getDBAPIContext(ctx context.Context, client *providerMeta, databaseID string) (string, string, error) {
+	previewClient := scalingo.NewPreviewClient(client.Client)
 
 	database, err := previewClient.DatabaseShow(ctx, databaseID)
 	if err != nil {
-		return "", "", fmt.Errorf("get database information for %v: %v", databaseID, err)
+		return "", "", fmt.Errorf("get database information for %v: %w", databaseID, err)
 	}
 
 	appID := database.App.ID
 	addons, err := client.AddonsList(ctx, appID)
 	if err != nil {
-		return "", "", fmt.Errorf("list addons: %v", err)
+		return "", "", fmt.Errorf("list addons: %w", err)
 	}
 	if len(addons) == 0 {
 		return "", "", fmt.Errorf("no addons found for database %v", databaseID)
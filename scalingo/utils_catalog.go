@@ -0,0 +1,89 @@
+package scalingo
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+// catalog caches the parts of the Scalingo catalog which don't change during
+// a Terraform run: addon providers and their plans, container sizes and
+// stacks. Each part is fetched the first time it is needed and shared by all
+// the resources and data sources of a provider instance, which may call it
+// concurrently.
+//
+// The returned slices are shared and must not be modified.
+type catalog struct {
+	client *scalingo.Client
+
+	addonProviders lazyValue[[]*scalingo.AddonProvider]
+	containerSizes lazyValue[[]scalingo.ContainerSize]
+	stacks         lazyValue[[]scalingo.Stack]
+
+	plansMu sync.Mutex
+	plans   map[string]*lazyValue[[]*scalingo.Plan]
+}
+
+func newCatalog(client *scalingo.Client) *catalog {
+	return &catalog{
+		client: client,
+		plans:  map[string]*lazyValue[[]*scalingo.Plan]{},
+	}
+}
+
+func (c *catalog) AddonProviders(ctx context.Context) ([]*scalingo.AddonProvider, error) {
+	return c.addonProviders.get(func() ([]*scalingo.AddonProvider, error) {
+		return c.client.AddonProvidersList(ctx)
+	})
+}
+
+func (c *catalog) AddonProviderPlans(ctx context.Context, providerID string) ([]*scalingo.Plan, error) {
+	c.plansMu.Lock()
+	plans, ok := c.plans[providerID]
+	if !ok {
+		plans = &lazyValue[[]*scalingo.Plan]{}
+		c.plans[providerID] = plans
+	}
+	c.plansMu.Unlock()
+
+	return plans.get(func() ([]*scalingo.Plan, error) {
+		return c.client.AddonProviderPlansList(ctx, providerID, scalingo.AddonProviderPlansListOpts{})
+	})
+}
+
+func (c *catalog) ContainerSizes(ctx context.Context) ([]scalingo.ContainerSize, error) {
+	return c.containerSizes.get(func() ([]scalingo.ContainerSize, error) {
+		return c.client.ContainerSizesList(ctx)
+	})
+}
+
+func (c *catalog) Stacks(ctx context.Context) ([]scalingo.Stack, error) {
+	return c.stacks.get(func() ([]scalingo.Stack, error) {
+		return c.client.StacksList(ctx)
+	})
+}
+
+// lazyValue is a value fetched on the first call to get. Unlike sync.Once,
+// errors are not kept: the value is fetched again by the next call.
+type lazyValue[T any] struct {
+	mu      sync.Mutex
+	fetched bool
+	value   T
+}
+
+func (l *lazyValue[T]) get(fetch func() (T, error)) (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.fetched {
+		return l.value, nil
+	}
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	l.value = value
+	l.fetched = true
+	return value, nil
+}
@@ -0,0 +1,108 @@
+package scalingo
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestCatalog_SharedByResources(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	for _, plan := range []string{"free", "basic", "free"} {
+		state := mustApplyResource(t, "scalingo_addon", meta, nil, map[string]any{
+			"app": app.ID, "provider_id": "mailjet", "plan": plan,
+		})
+		mustRefreshResource(t, "scalingo_addon", meta, state)
+	}
+	mustReadDataSource(t, "scalingo_addon_providers", meta, map[string]any{"name": "Mailjet"})
+
+	if count := f.requestCount(http.MethodGet, "/v1/addon_providers/mailjet/plans"); count != 1 {
+		t.Errorf("expected the plans to be listed once, got %d requests", count)
+	}
+	if count := f.requestCount(http.MethodGet, "/v1/addon_providers"); count != 1 {
+		t.Errorf("expected the addon providers to be listed once, got %d requests", count)
+	}
+}
+
+func TestCatalog_ConcurrentAccess(t *testing.T) {
+	f, meta := newTestProvider(t)
+	catalog := meta.(*providerMeta).catalog
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			for _, get := range []func() error{
+				func() error { _, err := catalog.AddonProviders(ctx); return err },
+				func() error { _, err := catalog.AddonProviderPlans(ctx, "postgresql"); return err },
+				func() error { _, err := catalog.ContainerSizes(ctx); return err },
+				func() error { _, err := catalog.Stacks(ctx); return err },
+			} {
+				if err := get(); err != nil {
+					t.Error(err)
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	for _, path := range []string{
+		"/v1/addon_providers",
+		"/v1/addon_providers/postgresql/plans",
+		"/v1/features/container_sizes",
+		"/v1/features/stacks",
+	} {
+		if count := f.requestCount(http.MethodGet, path); count != 1 {
+			t.Errorf("%s: expected a single request, got %d", path, count)
+		}
+	}
+}
+
+func TestCatalog_ErrorsAreNotCached(t *testing.T) {
+	f, meta := newTestProviderWithConfig(t, map[string]any{"max_retries": 0})
+	catalog := meta.(*providerMeta).catalog
+	f.failNext(http.MethodGet, "/v1/features/stacks", http.StatusInternalServerError, "")
+
+	if _, err := catalog.Stacks(context.Background()); err == nil {
+		t.Fatal("expected the first call to fail")
+	}
+	stacks, err := catalog.Stacks(context.Background())
+	if err != nil {
+		t.Fatalf("expected the second call to succeed: %v", err)
+	}
+	if len(stacks) != len(f.stacks) {
+		t.Errorf("expected %d stacks, got %d", len(f.stacks), len(stacks))
+	}
+}
+
+func TestCatalog_PerProviderInstance(t *testing.T) {
+	f, meta := newTestProvider(t)
+	mustReadDataSource(t, "scalingo_stack", meta, map[string]any{"name": "scalingo-22"})
+
+	// A second provider instance talking to the same API doesn't share the
+	// catalog of the first one.
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]any{
+		"api_token":    "tk-us-fake",
+		"api_url":      f.api.URL,
+		"db_api_url":   f.db.URL,
+		"auth_api_url": f.auth.URL,
+		"region":       f.region,
+	}))
+	if diags.HasError() {
+		t.Fatalf("configure provider: %v", diags)
+	}
+	mustReadDataSource(t, "scalingo_stack", p.Meta(), map[string]any{"name": "scalingo-22"})
+	mustReadDataSource(t, "scalingo_stack", meta, map[string]any{"name": "scalingo-24"})
+
+	if count := f.requestCount(http.MethodGet, "/v1/features/stacks"); count != 2 {
+		t.Errorf("expected the stacks to be listed once per provider, got %d requests", count)
+	}
+}
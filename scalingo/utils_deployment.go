@@ -0,0 +1,125 @@
+package scalingo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+// waitDeployment waits for the deployment to be finished. The build output is
+// streamed to the logs of the provider while waiting. If the deployment
+// fails, the returned error contains the last lines of its build output.
+//
+// The last known state of the deployment is returned even on error.
+func waitDeployment(ctx context.Context, client *providerMeta, appID string, deployment *scalingo.Deployment, timeout time.Duration) (*scalingo.Deployment, error) {
+	deploymentID := deployment.ID
+
+	streamCtx, stopStream := context.WithCancel(ctx)
+	streamDone := make(chan struct{})
+	go func() {
+		defer close(streamDone)
+		followDeploymentStream(streamCtx, client, appID, deploymentID)
+	}()
+	defer func() {
+		stopStream()
+		<-streamDone
+	}()
+
+	err := waitUntil(ctx, waitOptions{
+		timeout:   timeout,
+		operation: fmt.Sprintf("the deployment %v", deploymentID),
+		status: func() string {
+			return string(deployment.Status)
+		},
+	}, func() (bool, error) {
+		res, err := client.Deployment(ctx, appID, deploymentID)
+		if err != nil {
+			return false, fmt.Errorf("get deployment: %v", err)
+		}
+		deployment = res
+		return deployment.IsFinished(), nil
+	})
+	if err != nil {
+		return deployment, err
+	}
+
+	if deployment.HasFailed() {
+		return deployment, fmt.Errorf("deployment %v failed with status %v%s", deployment.ID, deployment.Status, deploymentOutputTail(ctx, client, deployment))
+	}
+	return deployment, nil
+}
+
+// followDeploymentStream forwards the build output of the deployment to the
+// logs of the provider until the context is canceled. It is best effort: the
+// status of the deployment is polled and doesn't rely on the stream.
+func followDeploymentStream(ctx context.Context, client *providerMeta, appID, deploymentID string) {
+	app, err := client.AppsShow(ctx, appID)
+	if err != nil {
+		tflog.Debug(ctx, "Fail to get the deployment stream URL, the build output won't be logged", map[string]any{"error": err.Error()})
+		return
+	}
+	if app.Links == nil || app.Links.DeploymentsStream == "" {
+		return
+	}
+
+	conn, err := client.DeploymentStream(ctx, app.Links.DeploymentsStream)
+	if err != nil {
+		tflog.Debug(ctx, "Fail to connect to the deployment stream, the build output won't be logged", map[string]any{"error": err.Error()})
+		return
+	}
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	for {
+		var event scalingo.DeploymentEvent
+		err := conn.ReadJSON(&event)
+		if err != nil {
+			return
+		}
+		if event.ID != deploymentID || event.Type != scalingo.DeploymentEventTypeLog {
+			continue
+		}
+
+		var data scalingo.DeploymentEventDataLog
+		err = json.Unmarshal(event.Data, &data)
+		if err != nil {
+			continue
+		}
+		for _, line := range strings.Split(strings.TrimRight(data.Content, "\n"), "\n") {
+			tflog.Info(ctx, line, map[string]any{"deployment": deploymentID})
+		}
+	}
+}
+
+// deploymentOutputTail returns the last lines of the build output of the
+// deployment, formatted to be appended to an error message. It is empty if
+// the output can't be fetched.
+func deploymentOutputTail(ctx context.Context, client *providerMeta, deployment *scalingo.Deployment) string {
+	if deployment.Links == nil || deployment.Links.Output == "" {
+		return ""
+	}
+
+	body, err := client.DeploymentLogs(ctx, deployment.Links.Output)
+	if err != nil {
+		tflog.Warn(ctx, "Fail to get the output of the deployment", map[string]any{"deployment": deployment.ID, "error": err.Error()})
+		return ""
+	}
+	defer body.Close()
+
+	output, err := io.ReadAll(body)
+	if err != nil {
+		tflog.Warn(ctx, "Fail to read the output of the deployment", map[string]any{"deployment": deployment.ID, "error": err.Error()})
+		return ""
+	}
+
+	return formatOutputTail("build output", output)
+}
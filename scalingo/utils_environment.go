@@ -2,11 +2,15 @@ package scalingo
 
 import (
 	"context"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/Scalingo/go-scalingo/v11"
 )
 
-func appEnvironment(ctx context.Context, client *scalingo.Client, appID string) (map[string]interface{}, error) {
+func appEnvironment(ctx context.Context, client *providerMeta, appID string) (map[string]interface{}, error) {
 	variables, err := client.VariablesList(ctx, appID)
 	if err != nil {
 		return nil, err
@@ -19,7 +23,19 @@ func appEnvironment(ctx context.Context, client *scalingo.Client, appID string)
 	return result, nil
 }
 
-func deleteVariablesByName(ctx context.Context, client *scalingo.Client, appID string, names []string) error {
+// deleteVariablesByName deletes the variables of the application with the
+// given names, except the ones managed by a scalingo_environment_variable
+// resource.
+func deleteVariablesByName(ctx context.Context, client *providerMeta, appID string, names []string) error {
+	var toDelete []string
+	for _, name := range names {
+		if client.variables.managedByResource(appID, name) {
+			tflog.Warn(ctx, "Environment variable managed by a scalingo_environment_variable resource, not deleting it", map[string]any{"app": appID, "name": name})
+			continue
+		}
+		toDelete = append(toDelete, name)
+	}
+	names = toDelete
 	if len(names) == 0 {
 		return nil
 	}
@@ -40,3 +56,109 @@ func deleteVariablesByName(ctx context.Context, client *scalingo.Client, appID s
 
 	return nil
 }
+
+// environmentChanges returns the variables to set and the names of the
+// variables to delete to apply the changes of the given environment map
+// attributes. A variable moved from one attribute to another is only set.
+func environmentChanges(d *schema.ResourceData, keys ...string) (scalingo.Variables, []string) {
+	var toSet scalingo.Variables
+	var deleted []string
+	newNames := map[string]bool{}
+
+	for _, key := range keys {
+		oldVariables, newVariables := d.GetChange(key)
+		variables, _ := newVariables.(map[string]interface{})
+		diff := MapDiff(oldVariables.(map[string]interface{}), variables)
+
+		for _, name := range append(diff.Added, diff.Modified...) {
+			toSet = append(toSet, &scalingo.Variable{
+				Name:  name,
+				Value: variables[name].(string),
+			})
+		}
+		deleted = append(deleted, diff.Deleted...)
+		for name := range variables {
+			newNames[name] = true
+		}
+	}
+
+	var toDelete []string
+	for _, name := range deleted {
+		if !newNames[name] {
+			toDelete = append(toDelete, name)
+		}
+	}
+	return toSet, toDelete
+}
+
+// environmentNames returns the names of the variables of the environment
+// maps.
+func environmentNames(environments ...map[string]interface{}) []string {
+	var names []string
+	for _, environment := range environments {
+		for name := range environment {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// variableOwners records which environment variables are managed by the
+// environment of a scalingo_app resource and which ones are managed by a
+// scalingo_environment_variable resource, to detect the variables managed by
+// both.
+//
+// The records are kept by provider instance: they only know about the
+// resources read or planned during the current Terraform run.
+type variableOwners struct {
+	mu sync.Mutex
+	// app and resource are keyed by application ID, then variable name.
+	app      map[string]map[string]bool
+	resource map[string]map[string]bool
+}
+
+// setAppEnvironment records the variables managed by the environment of the
+// application resource.
+func (o *variableOwners) setAppEnvironment(appID string, names []string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.app == nil {
+		o.app = map[string]map[string]bool{}
+	}
+	o.app[appID] = map[string]bool{}
+	for _, name := range names {
+		o.app[appID][name] = true
+	}
+}
+
+func (o *variableOwners) managedByApp(appID, name string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.app[appID][name]
+}
+
+// setManagedByResource records whether the variable is managed by a
+// scalingo_environment_variable resource.
+func (o *variableOwners) setManagedByResource(appID, name string, managed bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.resource == nil {
+		o.resource = map[string]map[string]bool{}
+	}
+	if o.resource[appID] == nil {
+		o.resource[appID] = map[string]bool{}
+	}
+	if managed {
+		o.resource[appID][name] = true
+	} else {
+		delete(o.resource[appID], name)
+	}
+}
+
+func (o *variableOwners) managedByResource(appID, name string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.resource[appID][name]
+}
@@ -0,0 +1,55 @@
+package scalingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+	httpclient "github.com/Scalingo/go-scalingo/v11/http"
+)
+
+// isNotFoundError returns true if the error means that the requested object
+// doesn't exist anymore on Scalingo: one of the APIs answered with a 404, or
+// the database doesn't exist. Resources looking their object up in a list
+// handle its absence themselves.
+func isNotFoundError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, scalingo.ErrDatabaseNotFound) {
+		return true
+	}
+
+	var requestFailedErr *httpclient.RequestFailedError
+	if errors.As(err, &requestFailedErr) {
+		return requestFailedErr.Code == http.StatusNotFound
+	}
+	return false
+}
+
+// deletionProtectionError is returned by the deletion of a resource whose
+// deletion_protection attribute is enabled.
+func deletionProtectionError(kind, name string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%v %v is protected against deletion", kind, name),
+		Detail:   "Set deletion_protection to false and apply this change before destroying or replacing it.",
+	}}
+}
+
+// removeFromState clears the ID of a resource which has been deleted outside
+// of Terraform, so that the next plan proposes to create it again instead of
+// failing the refresh.
+func removeFromState(ctx context.Context, d *schema.ResourceData, resourceType string) {
+	tflog.Warn(ctx, "Resource not found on Scalingo, removing it from the state", map[string]interface{}{
+		"resource_type": resourceType,
+		"id":            d.Id(),
+	})
+	d.SetId("")
+}
@@ -0,0 +1,239 @@
+package scalingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Scalingo/go-scalingo/v11"
+	httpclient "github.com/Scalingo/go-scalingo/v11/http"
+	errorutils "github.com/Scalingo/go-utils/errors/v3"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	ctx := context.Background()
+	notFound := &httpclient.RequestFailedError{Code: http.StatusNotFound, APIError: errors.New("not found")}
+	serverError := &httpclient.RequestFailedError{Code: http.StatusInternalServerError, APIError: errors.New("boom")}
+
+	tests := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"nil":                      {err: nil, expected: false},
+		"404":                      {err: notFound, expected: true},
+		"wrapped 404":              {err: errorutils.Wrap(ctx, notFound, "show app"), expected: true},
+		"404 wrapped with fmt":     {err: fmt.Errorf("get database: %w", notFound), expected: true},
+		"500":                      {err: serverError, expected: false},
+		"database missing in list": {err: errorutils.Wrap(ctx, scalingo.ErrDatabaseNotFound, "show database"), expected: true},
+		// Only the status code matters, not the message
+		"not found in message": {err: errors.New("user not found"), expected: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := isNotFoundError(test.err); actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+// TestRead_RemovesDeletedResources checks that every resource deleted outside
+// of Terraform is removed from the state on refresh, either because the
+// object itself or its parent application is gone.
+func TestRead_RemovesDeletedResources(t *testing.T) {
+	type testCase struct {
+		resource string
+		// create creates the resource and returns its state
+		create func(t *testing.T, f *fakeAPI, meta any, app *fakeApp) *terraform.InstanceState
+		// remove deletes the resource out of Terraform, it deletes the parent
+		// application when nil
+		remove func(f *fakeAPI, app *fakeApp)
+	}
+
+	apply := func(resource string, raw func(app *fakeApp) map[string]any) func(*testing.T, *fakeAPI, any, *fakeApp) *terraform.InstanceState {
+		return func(t *testing.T, _ *fakeAPI, meta any, app *fakeApp) *terraform.InstanceState {
+			return mustApplyResource(t, resource, meta, nil, raw(app))
+		}
+	}
+
+	tests := []testCase{
+		{
+			resource: "scalingo_app",
+			create: func(t *testing.T, _ *fakeAPI, meta any, _ *fakeApp) *terraform.InstanceState {
+				return mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "other-app"})
+			},
+			remove: func(f *fakeAPI, _ *fakeApp) { f.apps = f.apps[:1] },
+		}, {
+			resource: "scalingo_addon",
+			create: apply("scalingo_addon", func(app *fakeApp) map[string]any {
+				return map[string]any{"app": app.ID, "provider_id": "mailjet", "plan": "free"}
+			}),
+			remove: func(_ *fakeAPI, app *fakeApp) { app.addons = nil },
+		}, {
+			resource: "scalingo_addon",
+		}, {
+			resource: "scalingo_alert",
+			create: apply("scalingo_alert", func(app *fakeApp) map[string]any {
+				return map[string]any{"app": app.ID, "container_type": "web", "metric": "cpu", "limit": 0.5}
+			}),
+			remove: func(_ *fakeAPI, app *fakeApp) { app.alerts = nil },
+		}, {
+			resource: "scalingo_alert",
+		}, {
+			resource: "scalingo_autoscaler",
+			create: apply("scalingo_autoscaler", func(app *fakeApp) map[string]any {
+				return map[string]any{"app": app.ID, "container_type": "web", "min_containers": 1, "max_containers": 3, "metric": "cpu", "target": 0.5}
+			}),
+			remove: func(_ *fakeAPI, app *fakeApp) { app.autoscalers = nil },
+		}, {
+			resource: "scalingo_autoscaler",
+		}, {
+			resource: "scalingo_collaborator",
+			create: apply("scalingo_collaborator", func(app *fakeApp) map[string]any {
+				return map[string]any{"app": app.ID, "email": "collaborator@fake.test"}
+			}),
+			remove: func(_ *fakeAPI, app *fakeApp) { app.collaborators = nil },
+		}, {
+			resource: "scalingo_collaborator",
+		}, {
+			resource: "scalingo_container_type",
+		}, {
+			resource: "scalingo_database",
+			create: func(t *testing.T, _ *fakeAPI, meta any, _ *fakeApp) *terraform.InstanceState {
+				return mustApplyResource(t, "scalingo_database", meta, nil, map[string]any{
+					"name": "my-db", "technology": "postgresql-ng", "plan": "postgresql-ng-starter-4096",
+				})
+			},
+			remove: func(f *fakeAPI, _ *fakeApp) { f.apps = f.apps[:1] },
+		}, {
+			resource: "scalingo_database_firewall_rule",
+			create: func(t *testing.T, f *fakeAPI, meta any, _ *fakeApp) *terraform.InstanceState {
+				database := mustApplyResource(t, "scalingo_database", meta, nil, map[string]any{
+					"name": "my-db", "technology": "postgresql-ng", "plan": "postgresql-ng-starter-4096",
+				})
+				return mustApplyResource(t, "scalingo_database_firewall_rule", meta, nil, map[string]any{
+					"database_id": database.ID, "cidr": "203.0.113.0/24",
+				})
+			},
+			remove: func(f *fakeAPI, _ *fakeApp) { f.apps = f.apps[:1] },
+		}, {
+			resource: "scalingo_deployment",
+			create: apply("scalingo_deployment", func(app *fakeApp) map[string]any {
+				return map[string]any{"app": app.ID, "source_url": "https://example.test/archive.tar.gz"}
+			}),
+			remove: func(_ *fakeAPI, app *fakeApp) { app.deployments = nil },
+		}, {
+			resource: "scalingo_deployment",
+		}, {
+			resource: "scalingo_domain",
+			create: apply("scalingo_domain", func(app *fakeApp) map[string]any {
+				return map[string]any{"app": app.ID, "common_name": "www.example.test"}
+			}),
+			remove: func(_ *fakeAPI, app *fakeApp) { app.domains = nil },
+		}, {
+			resource: "scalingo_domain",
+		}, {
+			resource: "scalingo_environment_variable",
+			create: apply("scalingo_environment_variable", func(app *fakeApp) map[string]any {
+				return map[string]any{"app": app.ID, "name": "SENTRY_DSN", "value": "https://sentry.example.test"}
+			}),
+			remove: func(_ *fakeAPI, app *fakeApp) { app.variables = nil },
+		}, {
+			resource: "scalingo_environment_variable",
+		}, {
+			resource: "scalingo_log_drain",
+			create: apply("scalingo_log_drain", func(app *fakeApp) map[string]any {
+				return map[string]any{"app": app.ID, "type": "syslog", "host": "logs.example.test", "port": "514"}
+			}),
+			remove: func(_ *fakeAPI, app *fakeApp) { app.logDrains = nil },
+		}, {
+			resource: "scalingo_log_drain",
+		}, {
+			resource: "scalingo_notifier",
+			create: apply("scalingo_notifier", func(app *fakeApp) map[string]any {
+				return map[string]any{"app": app.ID, "name": "hook", "platform_id": "np-webhook", "webhook_url": "https://hooks.example.test"}
+			}),
+			remove: func(_ *fakeAPI, app *fakeApp) { app.notifiers = nil },
+		}, {
+			resource: "scalingo_notifier",
+		}, {
+			resource: "scalingo_project",
+			create: apply("scalingo_project", func(_ *fakeApp) map[string]any {
+				return map[string]any{"name": "my-project"}
+			}),
+			remove: func(f *fakeAPI, _ *fakeApp) { f.projects = nil },
+		}, {
+			resource: "scalingo_scm_integration",
+			create: apply("scalingo_scm_integration", func(_ *fakeApp) map[string]any {
+				return map[string]any{"scm_type": "gitlab-self-hosted", "url": "https://gitlab.example.test", "access_token": "secret"}
+			}),
+			remove: func(f *fakeAPI, _ *fakeApp) { f.scmIntegrations = nil },
+		}, {
+			resource: "scalingo_scm_repo_link",
+			create: apply("scalingo_scm_repo_link", func(app *fakeApp) map[string]any {
+				return map[string]any{"app": app.ID, "auth_integration_uuid": "scm-1", "source": "https://github.com/owner/repo", "branch": "main"}
+			}),
+			remove: func(_ *fakeAPI, app *fakeApp) { app.scmRepoLink = nil },
+		}, {
+			resource: "scalingo_scm_repo_link",
+		}, {
+			resource: "scalingo_ssh_key",
+			create: apply("scalingo_ssh_key", func(_ *fakeApp) map[string]any {
+				return map[string]any{"key_name": "laptop", "public_key": testPublicKey}
+			}),
+			remove: func(f *fakeAPI, _ *fakeApp) { f.keys = nil },
+		},
+	}
+
+	// Resources attached to an application are also removed from the state when
+	// the application is deleted.
+	defaultCreate := map[string]func(*testing.T, *fakeAPI, any, *fakeApp) *terraform.InstanceState{}
+	for _, test := range tests {
+		if test.create != nil {
+			if _, ok := defaultCreate[test.resource]; !ok {
+				defaultCreate[test.resource] = test.create
+			}
+		}
+	}
+	defaultCreate["scalingo_container_type"] = apply("scalingo_container_type", func(app *fakeApp) map[string]any {
+		return map[string]any{"app": app.ID, "name": "worker", "amount": 1}
+	})
+
+	for _, test := range tests {
+		name := test.resource + "/object deleted"
+		if test.remove == nil {
+			name = test.resource + "/app deleted"
+		}
+		t.Run(name, func(t *testing.T) {
+			f, meta := newTestProvider(t)
+			app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+			create := test.create
+			if create == nil {
+				create = defaultCreate[test.resource]
+			}
+			state := create(t, f, meta, app)
+
+			f.mu.Lock()
+			if test.remove != nil {
+				test.remove(f, app)
+			} else {
+				f.apps = nil
+			}
+			f.mu.Unlock()
+
+			newState, diags := refreshResource(t, test.resource, meta, state)
+			if diags.HasError() {
+				t.Fatalf("refresh: %v", diags)
+			}
+			if newState != nil {
+				t.Errorf("expected the resource to be removed from the state, got %v", newState)
+			}
+		})
+	}
+}
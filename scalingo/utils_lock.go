@@ -0,0 +1,91 @@
+package scalingo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// appLocks serializes the operations mutating a same application. Terraform
+// applies resources in parallel and the API rejects concurrent operations on
+// an application, e.g. two scale operations or a scale during a restart.
+//
+// Locks are keyed by the ID of the application, so that the resources
+// referencing it by name and the ones referencing it by ID wait for each
+// other.
+type appLocks struct {
+	mu sync.Mutex
+	// locks are semaphores of capacity 1 rather than mutexes, so that waiting
+	// for them can be canceled.
+	locks map[string]chan struct{}
+	// ids caches the ID of the applications by identifier as configured.
+	ids map[string]string
+}
+
+// lockApp blocks until no other resource of the provider is mutating the
+// application, or until the context is done, e.g. when the timeout of the
+// resource is reached. The returned function releases the lock, it must be
+// called once the API accepts operations on the application again, which may
+// be before the end of the resource operation. Calling it again is a no-op.
+func (m *providerMeta) lockApp(ctx context.Context, app string) (func(), error) {
+	appID := m.appLockKey(ctx, app)
+
+	m.appLocks.mu.Lock()
+	if m.appLocks.locks == nil {
+		m.appLocks.locks = map[string]chan struct{}{}
+	}
+	lock, ok := m.appLocks.locks[appID]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		m.appLocks.locks[appID] = lock
+	}
+	m.appLocks.mu.Unlock()
+
+	var once sync.Once
+	unlock := func() { once.Do(func() { <-lock }) }
+	select {
+	case lock <- struct{}{}:
+		return unlock, nil
+	default:
+	}
+
+	tflog.Debug(ctx, "Waiting for another operation on the application to finish", map[string]any{"app": app})
+	start := time.Now()
+	select {
+	case lock <- struct{}{}:
+		tflog.Debug(ctx, "Application lock acquired", map[string]any{"app": app, "waited": time.Since(start).String()})
+		return unlock, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("wait for another operation on application %v to finish: %w", app, ctx.Err())
+	}
+}
+
+// appLockKey returns the ID of the application given by ID or name. It falls
+// back to the identifier as configured if the application can't be fetched,
+// e.g. when it has been deleted: the mutation then fails on its own.
+func (m *providerMeta) appLockKey(ctx context.Context, app string) string {
+	m.appLocks.mu.Lock()
+	appID, ok := m.appLocks.ids[app]
+	m.appLocks.mu.Unlock()
+	if ok {
+		return appID
+	}
+
+	res, err := m.AppsShow(ctx, app)
+	if err != nil {
+		tflog.Debug(ctx, "Fail to fetch the application to lock, locking it by its identifier", map[string]any{"app": app, "error": err.Error()})
+		return app
+	}
+
+	m.appLocks.mu.Lock()
+	defer m.appLocks.mu.Unlock()
+	if m.appLocks.ids == nil {
+		m.appLocks.ids = map[string]string{}
+	}
+	m.appLocks.ids[app] = res.ID
+	m.appLocks.ids[res.ID] = res.ID
+	return res.ID
+}
@@ -0,0 +1,136 @@
+package scalingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestLockApp(t *testing.T) {
+	ctx := context.Background()
+	f, rawMeta := newTestProvider(t)
+	meta, _ := rawMeta.(*providerMeta)
+	f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	f.createApp(scalingo.AppsCreateOpts{Name: "other-app"})
+
+	unlock, err := meta.lockApp(ctx, "my-app")
+	if err != nil {
+		t.Fatalf("lock application: %v", err)
+	}
+
+	otherApp := make(chan struct{})
+	go func() {
+		unlockOther, err := meta.lockApp(ctx, "other-app")
+		if err == nil {
+			unlockOther()
+		}
+		close(otherApp)
+	}()
+	select {
+	case <-otherApp:
+	case <-time.After(time.Second):
+		t.Fatal("the lock of another application should not be held")
+	}
+
+	sameApp := make(chan struct{})
+	go func() {
+		unlockSame, err := meta.lockApp(ctx, "my-app")
+		if err == nil {
+			unlockSame()
+		}
+		close(sameApp)
+	}()
+	select {
+	case <-sameApp:
+		t.Fatal("the lock of the application should be held")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case <-sameApp:
+	case <-time.After(time.Second):
+		t.Fatal("the lock of the application should have been released")
+	}
+}
+
+func TestLockApp_ByNameAndID(t *testing.T) {
+	ctx := context.Background()
+	f, rawMeta := newTestProvider(t)
+	meta, _ := rawMeta.(*providerMeta)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	// An application referenced by name by a resource and by ID by another is
+	// locked once.
+	unlock, err := meta.lockApp(ctx, app.Name)
+	if err != nil {
+		t.Fatalf("lock application: %v", err)
+	}
+	defer unlock()
+
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = meta.lockApp(waitCtx, app.ID)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the lock of the application by ID to be held, got %v", err)
+	}
+}
+
+func TestLockApp_Canceled(t *testing.T) {
+	f, rawMeta := newTestProvider(t)
+	meta, _ := rawMeta.(*providerMeta)
+	f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	unlock, err := meta.lockApp(context.Background(), "my-app")
+	if err != nil {
+		t.Fatalf("lock application: %v", err)
+	}
+	defer unlock()
+
+	// Waiting for the lock stops with the context, e.g. at the timeout of the
+	// resource.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = meta.lockApp(ctx, "my-app")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to stop at the deadline, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "wait for another operation on application my-app to finish") {
+		t.Errorf("expected the error to name the application, got %v", err)
+	}
+}
+
+func TestLockApp_ParallelScales(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	// The fake API rejects a scale while another operation of the application
+	// is in progress, parallel applies must wait for each other.
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := range 5 {
+		wg.Go(func() {
+			_, diags := applyResource(t, "scalingo_container_type", meta, nil, map[string]any{
+				"app": app.ID, "name": fmt.Sprintf("worker-%d", i), "amount": 1,
+			})
+			if diags.HasError() {
+				errs <- fmt.Errorf("worker-%d: %v", i, diags)
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+	if len(app.operations) != 5 {
+		t.Errorf("expected 5 scale operations, got %d", len(app.operations))
+	}
+}
@@ -2,20 +2,24 @@ package scalingo
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
 
 	"github.com/Scalingo/go-scalingo/v11"
 )
 
-func waitOperation(ctx context.Context, client *scalingo.Client, location string) error {
+// waitOperation waits for the operation at the given location to be done.
+// The operation is described as e.g. "the application restart" in the errors.
+func waitOperation(ctx context.Context, client *providerMeta, location, operation string, timeout time.Duration) error {
 	var err error
 
 	op := &scalingo.Operation{}
 	return waitUntil(ctx, waitOptions{
-		timeout:    5 * time.Minute,
-		timeoutErr: errors.New("restart operation timeout"),
+		timeout:   timeout,
+		operation: operation,
+		status: func() string {
+			return string(op.Status)
+		},
 	}, func() (bool, error) {
 		op, err = client.OperationsShowFromURL(ctx, location)
 		if err != nil {
@@ -25,8 +29,19 @@ func waitOperation(ctx context.Context, client *scalingo.Client, location string
 			return true, nil
 		}
 		if op.Status == scalingo.OperationStatusError {
-			return false, fmt.Errorf("restart operation failed %v", op.Error)
+			return false, &operationFailedError{operation: operation, reason: op.Error}
 		}
 		return false, nil
 	})
 }
+
+// operationFailedError is returned by waitOperation when the operation ended
+// with an error.
+type operationFailedError struct {
+	operation string
+	reason    string
+}
+
+func (err *operationFailedError) Error() string {
+	return fmt.Sprintf("%s failed: %v", err.operation, err.reason)
+}
@@ -0,0 +1,177 @@
+package scalingo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultMaxRetries   = 5
+	defaultRetryMaxWait = "30s"
+)
+
+// retryBaseDelay is the delay before the first retry, it doubles at each
+// attempt. It is a variable so that tests can retry faster.
+var retryBaseDelay = 1 * time.Second
+
+// retryTransport retries the requests which failed because of a transient
+// error of the Scalingo APIs: a 5xx answer to an idempotent request, or a 429
+// answer to any request as it has not been processed.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+	// timeout applies to each attempt rather than to the whole request, so that
+	// the time spent waiting between attempts isn't deducted from it.
+	timeout time.Duration
+
+	// logCtx carries the provider logger: go-scalingo doesn't attach any
+	// context to the requests it builds.
+	logCtx context.Context
+}
+
+// setupRetries makes every request sent through the given HTTP client go
+// through a retryTransport.
+func setupRetries(ctx context.Context, httpClient *http.Client, maxRetries int, maxWait time.Duration) {
+	next := httpClient.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	httpClient.Transport = &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		maxWait:    maxWait,
+		timeout:    httpClient.Timeout,
+		logCtx:     ctx,
+	}
+	httpClient.Timeout = 0
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		res, err := t.roundTrip(req, attempt)
+		if err != nil || attempt >= t.maxRetries || !isRetryableResponse(req, res) || !canReplay(req) {
+			return res, err
+		}
+
+		wait := t.retryDelay(attempt, res)
+		tflog.Warn(t.logCtx, "Scalingo API request failed with a transient error, retrying", map[string]any{
+			"method":      req.Method,
+			"url":         req.URL.Redacted(),
+			"status":      res.StatusCode,
+			"attempt":     attempt + 1,
+			"max_retries": t.maxRetries,
+			"wait":        wait.String(),
+		})
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// roundTrip sends a single attempt of the request, with its own timeout.
+func (t *retryTransport) roundTrip(req *http.Request, attempt int) (*http.Response, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+	}
+
+	attemptReq := req.Clone(ctx)
+	if attempt > 0 && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("rewind the body of the request: %w", err)
+		}
+		attemptReq.Body = body
+	}
+
+	res, err := t.next.RoundTrip(attemptReq)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The attempt context must outlive this function for the body to be read.
+	res.Body = &cancelOnCloseBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// retryDelay returns how long to wait before the next attempt: the delay
+// asked by the API through the Retry-After header if any, an exponential
+// backoff with jitter otherwise. It never exceeds maxWait.
+func (t *retryTransport) retryDelay(attempt int, res *http.Response) time.Duration {
+	if delay, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+		return min(delay, t.maxWait)
+	}
+
+	delay := t.maxWait
+	// Avoid overflowing the duration with large attempt numbers
+	if attempt < 32 {
+		delay = min(retryBaseDelay<<attempt, t.maxWait)
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	return max(time.Until(date), 0), true
+}
+
+func isRetryableResponse(req *http.Request, res *http.Response) bool {
+	if res.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if res.StatusCode < 500 || res.StatusCode == http.StatusNotImplemented {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// canReplay returns true if the body of the request can be sent again.
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
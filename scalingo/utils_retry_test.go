@@ -0,0 +1,146 @@
+package scalingo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestRetryTransport_RetriesTransientErrors(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+	state := mustImportResource(t, "scalingo_app", meta, app.ID)
+
+	f.failNext(http.MethodGet, "/v1/apps/"+app.ID, http.StatusBadGateway, "")
+	f.failNext(http.MethodGet, "/v1/apps/"+app.ID, http.StatusTooManyRequests, "0")
+	mustRefreshResource(t, "scalingo_app", meta, state)
+	// One request for the import, three for the refresh.
+	if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID); count != 4 {
+		t.Errorf("expected the request to be retried twice, got %d requests", count)
+	}
+}
+
+func TestRetryTransport_ReplaysRequestBody(t *testing.T) {
+	f, meta := newTestProvider(t)
+	state := mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+
+	f.failNext(http.MethodPut, "/v1/apps/"+state.ID, http.StatusServiceUnavailable, "")
+	state = mustApplyResource(t, "scalingo_app", meta, state, map[string]any{
+		"name":       "my-app",
+		"project_id": "pr-other",
+	})
+	assertAttr(t, state, "project_id", "pr-other")
+	if count := f.requestCount(http.MethodPut, "/v1/apps/"+state.ID); count != 2 {
+		t.Errorf("expected the request to be retried once, got %d requests", count)
+	}
+}
+
+func TestRetryTransport_NonIdempotentRequests(t *testing.T) {
+	t.Run("5xx is not retried", func(t *testing.T) {
+		f, meta := newTestProvider(t)
+		f.failNext(http.MethodPost, "/v1/apps", http.StatusInternalServerError, "")
+
+		_, diags := applyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+		assertDiagContains(t, diags, "create app")
+		if count := f.requestCount(http.MethodPost, "/v1/apps"); count != 1 {
+			t.Errorf("expected a single request, got %d", count)
+		}
+	})
+
+	t.Run("429 is retried", func(t *testing.T) {
+		f, meta := newTestProvider(t)
+		f.failNext(http.MethodPost, "/v1/apps", http.StatusTooManyRequests, "")
+
+		mustApplyResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app"})
+		if count := f.requestCount(http.MethodPost, "/v1/apps"); count != 2 {
+			t.Errorf("expected the request to be retried once, got %d requests", count)
+		}
+		if len(f.apps) != 1 {
+			t.Errorf("expected a single app to be created, got %d", len(f.apps))
+		}
+	})
+}
+
+func TestRetryTransport_MaxRetries(t *testing.T) {
+	tests := map[string]struct {
+		maxRetries       int
+		expectedRequests int
+	}{
+		"disabled":  {maxRetries: 0, expectedRequests: 1},
+		"exhausted": {maxRetries: 2, expectedRequests: 3},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, meta := newTestProviderWithConfig(t, map[string]any{"max_retries": test.maxRetries})
+			app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+			for range 3 {
+				f.failNext(http.MethodGet, "/v1/apps/"+app.ID, http.StatusServiceUnavailable, "")
+			}
+
+			_, diags := refreshResource(t, "scalingo_app", meta, &terraform.InstanceState{ID: app.ID})
+			if !diags.HasError() {
+				t.Fatal("expected the refresh to fail")
+			}
+			if count := f.requestCount(http.MethodGet, "/v1/apps/"+app.ID); count != test.expectedRequests {
+				t.Errorf("expected %d requests, got %d", test.expectedRequests, count)
+			}
+		})
+	}
+}
+
+func TestRetryTransport_RetryDelay(t *testing.T) {
+	transport := &retryTransport{maxWait: 10 * time.Second}
+	response := func(retryAfter string) *http.Response {
+		res := &http.Response{Header: http.Header{}}
+		if retryAfter != "" {
+			res.Header.Set("Retry-After", retryAfter)
+		}
+		return res
+	}
+
+	if delay := transport.retryDelay(0, response("3")); delay != 3*time.Second {
+		t.Errorf("expected Retry-After to be honoured, got %v", delay)
+	}
+	if delay := transport.retryDelay(0, response("60")); delay != 10*time.Second {
+		t.Errorf("expected Retry-After to be capped by the max wait, got %v", delay)
+	}
+	if delay := transport.retryDelay(0, response(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))); delay != 0 {
+		t.Errorf("expected no delay for a Retry-After date in the past, got %v", delay)
+	}
+
+	for attempt := range 3 {
+		backoff := retryBaseDelay << attempt
+		if delay := transport.retryDelay(attempt, response("invalid")); delay < backoff/2 || delay > backoff {
+			t.Errorf("attempt %d: expected a delay between %v and %v, got %v", attempt, backoff/2, backoff, delay)
+		}
+	}
+	for _, attempt := range []int{20, 100} {
+		if delay := transport.retryDelay(attempt, response("")); delay < 5*time.Second || delay > 10*time.Second {
+			t.Errorf("attempt %d: expected the backoff to be capped by the max wait, got %v", attempt, delay)
+		}
+	}
+}
+
+func TestProviderConfigure_InvalidRetrySettings(t *testing.T) {
+	tests := map[string]struct {
+		config   map[string]any
+		expected string
+	}{
+		"negative max_retries":   {config: map[string]any{"max_retries": -1}, expected: "invalid max_retries"},
+		"invalid retry_max_wait": {config: map[string]any{"retry_max_wait": "forever"}, expected: "invalid retry_max_wait"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.config["region"] = "osc-fr1"
+			diags := Provider().Configure(context.Background(), terraform.NewResourceConfigRaw(test.config))
+			assertDiagContains(t, diags, test.expected)
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package scalingo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// sourceArchivePrefix is the root directory of the source archives: the
+// platform expects the code in a single top-level directory, as in the
+// archives generated by `git archive --prefix` or GitHub.
+const sourceArchivePrefix = "source/"
+
+// packSource writes a gzipped tarball of the directory to w, skipping the
+// files matching the exclude patterns, and returns a hash of its content.
+//
+// The hash only depends on the paths, executable bits and contents of the
+// packed files: touching a file doesn't change it.
+func packSource(dir string, excludes []string, w io.Writer) (string, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%v is not a directory", dir)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	hash := sha256.New()
+
+	// WalkDir visits the files in lexical order which makes the hash
+	// deterministic.
+	err = filepath.WalkDir(dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if isExcluded(rel, entry.IsDir(), excludes) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		link := ""
+		switch {
+		case info.Mode()&fs.ModeSymlink != 0:
+			link, err = os.Readlink(p)
+			if err != nil {
+				return err
+			}
+		case !info.Mode().IsRegular() && !info.IsDir():
+			// Sockets, devices, etc. can't be deployed.
+			return nil
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = sourceArchivePrefix + rel
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		// Owners are meaningless on the platform and would make the
+		// archive depend on the machine running Terraform.
+		header.Uid, header.Gid, header.Uname, header.Gname = 0, 0, "", ""
+		err = tw.WriteHeader(header)
+		if err != nil {
+			return err
+		}
+		// Only the executable bit is hashed: the other permissions depend on
+		// the umask of the machine.
+		fmt.Fprintf(hash, "%v\x00%v\x00%v\x00", header.Name, header.Mode&0o111 != 0, link)
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(io.MultiWriter(tw, hash), f)
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("pack %v: %v", dir, err)
+	}
+
+	err = tw.Close()
+	if err != nil {
+		return "", fmt.Errorf("pack %v: %v", dir, err)
+	}
+	err = gz.Close()
+	if err != nil {
+		return "", fmt.Errorf("pack %v: %v", dir, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// isExcluded returns true if the path, relative to the source directory and
+// slash separated, matches one of the exclude patterns. The patterns follow
+// the syntax of a .slugignore file:
+//
+//   - a pattern is a glob as understood by path.Match, e.g. "*.log";
+//   - a pattern without slash matches a file or directory at any depth, e.g.
+//     "tmp" excludes "tmp" and "assets/tmp";
+//   - a pattern containing a slash is relative to the source directory, e.g.
+//     "/docs" or "spec/fixtures";
+//   - a pattern ending with a slash only matches directories.
+//
+// Excluding a directory excludes all its content.
+func isExcluded(rel string, isDir bool, excludes []string) bool {
+	for _, pattern := range excludes {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		dirOnly := strings.HasSuffix(pattern, "/")
+		pattern = strings.TrimSuffix(pattern, "/")
+		if dirOnly && !isDir {
+			continue
+		}
+
+		name := rel
+		if strings.Contains(pattern, "/") {
+			pattern = strings.TrimPrefix(pattern, "/")
+		} else {
+			name = path.Base(rel)
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// uploadSource uploads the archive to the upload URL returned by the Sources
+// API.
+func uploadSource(ctx context.Context, uploadURL string, archive []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(archive))
+	if err != nil {
+		return fmt.Errorf("create upload request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-gzip")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload source archive: %v", err)
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("upload source archive: unexpected status %v", res.Status)
+	}
+	return nil
+}
+
+// checkSourceReachable checks that the archive can be downloaded without
+// credentials, as the platform does to deploy it. The archives of private
+// repositories can't.
+func checkSourceReachable(ctx context.Context, sourceURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, sourceURL, nil)
+	if err != nil {
+		return fmt.Errorf("create source archive request: %v", err)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("check source archive %v: %v", sourceURL, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("source archive %v can't be downloaded without credentials (%v), private repositories aren't supported", sourceURL, res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package scalingo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// writeSourceFiles creates the files in the directory, the keys being slash
+// separated paths. Files whose name starts with "bin/" are executable.
+func writeSourceFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		err := os.MkdirAll(filepath.Dir(p), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mode := os.FileMode(0o644)
+		if filepath.Base(filepath.Dir(p)) == "bin" {
+			mode = 0o755
+		}
+		err = os.WriteFile(p, []byte(content), mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// archiveFiles returns the regular files of a gzipped tarball and their
+// content.
+func archiveFiles(t *testing.T, archive []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+
+	files := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(content)
+	}
+}
+
+func TestPackSource(t *testing.T) {
+	dir := t.TempDir()
+	writeSourceFiles(t, dir, map[string]string{
+		"server.js":         "console.log('hello')",
+		"bin/start":         "#!/bin/sh",
+		"logs/server.log":   "log",
+		"docs/index.md":     "documentation",
+		"lib/docs/index.md": "not at the root",
+		"assets/tmp/cache":  "cache",
+		"tmp":               "a file, not a directory",
+		".git/HEAD":         "ref: refs/heads/main",
+	})
+	excludes := []string{".git", "*.log", "/docs", "tmp/", "# a comment", ""}
+
+	var archive bytes.Buffer
+	hash, err := packSource(dir, excludes, &archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := archiveFiles(t, archive.Bytes())
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	expected := []string{"source/bin/start", "source/lib/docs/index.md", "source/server.js", "source/tmp"}
+	if !slices.Equal(names, expected) {
+		t.Errorf("expected files %v, got %v", expected, names)
+	}
+	if files["source/server.js"] != "console.log('hello')" {
+		t.Errorf("unexpected content %q", files["source/server.js"])
+	}
+
+	// The hash doesn't depend on the modification times.
+	future := time.Now().Add(time.Hour)
+	err = os.Chtimes(filepath.Join(dir, "server.js"), future, future)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sameHash, err := packSource(dir, excludes, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sameHash != hash {
+		t.Error("expected the hash not to change when a file is touched")
+	}
+
+	// It doesn't depend on the excluded files either.
+	writeSourceFiles(t, dir, map[string]string{"logs/other.log": "log"})
+	sameHash, _ = packSource(dir, excludes, io.Discard)
+	if sameHash != hash {
+		t.Error("expected the hash not to change when an excluded file is added")
+	}
+
+	writeSourceFiles(t, dir, map[string]string{"server.js": "console.log('bye')"})
+	newHash, _ := packSource(dir, excludes, io.Discard)
+	if newHash == hash {
+		t.Error("expected the hash to change with the content of a file")
+	}
+
+	err = os.Chmod(filepath.Join(dir, "server.js"), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	modeHash, _ := packSource(dir, excludes, io.Discard)
+	if modeHash == newHash {
+		t.Error("expected the hash to change when a file becomes executable")
+	}
+}
+
+func TestPackSource_NotADirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeSourceFiles(t, dir, map[string]string{"file": "content"})
+
+	_, err := packSource(filepath.Join(dir, "file"), nil, io.Discard)
+	if err == nil {
+		t.Error("expected an error for a file")
+	}
+	_, err = packSource(filepath.Join(dir, "missing"), nil, io.Discard)
+	if err == nil {
+		t.Error("expected an error for a missing directory")
+	}
+}
+
+func TestIsExcluded(t *testing.T) {
+	tests := []struct {
+		rel      string
+		isDir    bool
+		pattern  string
+		expected bool
+	}{
+		{rel: "node_modules", isDir: true, pattern: "node_modules", expected: true},
+		{rel: "web/node_modules", isDir: true, pattern: "node_modules", expected: true},
+		{rel: "a/b/debug.log", pattern: "*.log", expected: true},
+		{rel: "docs", isDir: true, pattern: "/docs", expected: true},
+		{rel: "lib/docs", isDir: true, pattern: "/docs", expected: false},
+		{rel: "spec/fixtures", isDir: true, pattern: "spec/fixtures", expected: true},
+		{rel: "tmp", pattern: "tmp/", expected: false},
+		{rel: "tmp", isDir: true, pattern: "tmp/", expected: true},
+		{rel: "server.js", pattern: "*.log", expected: false},
+	}
+
+	for _, test := range tests {
+		if excluded := isExcluded(test.rel, test.isDir, []string{test.pattern}); excluded != test.expected {
+			t.Errorf("isExcluded(%q, %v, %q): expected %v, got %v", test.rel, test.isDir, test.pattern, test.expected, excluded)
+		}
+	}
+}
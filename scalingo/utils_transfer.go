@@ -0,0 +1,127 @@
+package scalingo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+// appTransfers records the applications whose planned transfer would remove
+// the access of the provider user. The application resource is planned
+// before the resources targeting it, which refuse to be planned if they
+// would be orphaned by the transfer.
+type appTransfers struct {
+	mu sync.Mutex
+	// orphaning is indexed by application ID and name, the values are the
+	// emails of the new owners.
+	orphaning map[string]string
+}
+
+// setOrphaning records the orphaning transfer of the application with the
+// given ID and name, an empty email clears it. The ID is empty for an
+// application being created.
+func (t *appTransfers) setOrphaning(appID, appName, ownerEmail string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.orphaning == nil {
+		t.orphaning = map[string]string{}
+	}
+
+	for _, key := range []string{appID, appName} {
+		if key == "" {
+			continue
+		}
+		if ownerEmail == "" {
+			delete(t.orphaning, key)
+		} else {
+			t.orphaning[key] = ownerEmail
+		}
+	}
+}
+
+func (t *appTransfers) orphaningOwner(app string) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ownerEmail, ok := t.orphaning[app]
+	return ownerEmail, ok
+}
+
+// keepsAccessAfterTransfer returns true if the provider user can still manage
+// the application once transferred to the given email: either the user is
+// the new owner or an accepted, non-limited, collaborator of the application.
+//
+// An application being created, with an empty ID, has no collaborator yet.
+func keepsAccessAfterTransfer(ctx context.Context, client *providerMeta, appID, ownerEmail string) (bool, error) {
+	user, err := client.Self(ctx)
+	if err != nil {
+		return false, fmt.Errorf("get provider user: %v", err)
+	}
+	if strings.EqualFold(user.Email, ownerEmail) {
+		return true, nil
+	}
+	if appID == "" {
+		return false, nil
+	}
+
+	collaborators, err := client.CollaboratorsList(ctx, appID)
+	if err != nil {
+		return false, fmt.Errorf("list collaborators: %v", err)
+	}
+	for _, collaborator := range collaborators {
+		if collaborator.UserID != user.ID && !strings.EqualFold(collaborator.Email, user.Email) {
+			continue
+		}
+		if collaborator.Status == scalingo.CollaboratorStatusAccepted && !collaborator.IsLimited {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// refuseOrphaningTransfer is added to the CustomizeDiff of the resources
+// targeting an application. It fails the plan of the resource if its
+// application is transferred to an owner and the provider user would lose
+// its access to it: the resource couldn't be refreshed, updated nor
+// destroyed anymore.
+func refuseOrphaningTransfer(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("app") {
+		return nil
+	}
+	client, _ := meta.(*providerMeta)
+
+	app, _ := d.Get("app").(string)
+	ownerEmail, ok := client.transfers.orphaningOwner(app)
+	if !ok {
+		return nil
+	}
+	return fmt.Errorf("the transfer of application %v to %v would orphan this resource: the provider user wouldn't be able to manage it anymore, "+
+		"add the provider user as a collaborator of the application before the transfer or remove this resource from the state with `terraform state rm`", app, ownerEmail)
+}
+
+// withOrphaningTransferCheck adds refuseOrphaningTransfer to the resources
+// having an `app` attribute.
+func withOrphaningTransferCheck(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, resource := range resources {
+		if _, ok := resource.Schema["app"]; !ok {
+			continue
+		}
+		customizeDiff := resource.CustomizeDiff
+		if customizeDiff == nil {
+			resource.CustomizeDiff = refuseOrphaningTransfer
+			continue
+		}
+		resource.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			err := refuseOrphaningTransfer(ctx, d, meta)
+			if err != nil {
+				return err
+			}
+			return customizeDiff(ctx, d, meta)
+		}
+	}
+	return resources
+}
@@ -0,0 +1,206 @@
+package scalingo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+// metrics are the metrics which can be watched by alerts and autoscalers.
+var metrics = []string{
+	scalingo.MetricCPU,
+	scalingo.MetricMemory,
+	scalingo.MetricSwap,
+	scalingo.MetricRouter5XX,
+	scalingo.MetricRouterAll,
+	scalingo.MetricRouterServersAmount,
+	scalingo.MetricRouterRPMPerContainer,
+	scalingo.MetricRouterP95ResponseTime,
+}
+
+// validateValue returns an error if the value is not one of the possible
+// values. The error suggests the closest possible values to fix a typo.
+func validateValue(description, value string, possibleValues []string) error {
+	if slices.Contains(possibleValues, value) {
+		return nil
+	}
+
+	msg := fmt.Sprintf("%q is not a valid %s", value, description)
+	if suggestions := closestValues(value, possibleValues); len(suggestions) > 0 {
+		quoted := make([]string, 0, len(suggestions))
+		for _, suggestion := range suggestions {
+			quoted = append(quoted, fmt.Sprintf("%q", suggestion))
+		}
+		msg += fmt.Sprintf(", did you mean %s?", strings.Join(quoted, " or "))
+	} else {
+		msg += "."
+	}
+	msg += fmt.Sprintf(" Possible values are: %s", strings.Join(possibleValues, ", "))
+	return errors.New(msg)
+}
+
+// shouldValidate returns true if the attribute is going to be changed to a
+// value known at plan time.
+func shouldValidate(d *schema.ResourceDiff, key string) bool {
+	if !d.HasChange(key) || !d.NewValueKnown(key) {
+		return false
+	}
+	value, _ := d.Get(key).(string)
+	return value != ""
+}
+
+func validateAddonPlan(ctx context.Context, client *providerMeta, providerID, plan string) error {
+	plans, err := client.catalog.AddonProviderPlans(ctx, providerID)
+	if isNotFoundError(err) {
+		providers, err := client.catalog.AddonProviders(ctx)
+		if err != nil {
+			return fmt.Errorf("list addon providers: %v", err)
+		}
+		providerIDs := make([]string, 0, len(providers))
+		for _, provider := range providers {
+			providerIDs = append(providerIDs, provider.ID)
+		}
+		return validateValue("addon provider", providerID, providerIDs)
+	}
+	if err != nil {
+		return fmt.Errorf("list the plans of addon provider %v: %v", providerID, err)
+	}
+
+	planNames := make([]string, 0, len(plans))
+	for _, plan := range plans {
+		planNames = append(planNames, plan.Name)
+	}
+	return validateValue(fmt.Sprintf("plan for addon provider %v", providerID), plan, planNames)
+}
+
+func validateContainerSize(ctx context.Context, client *providerMeta, size string) error {
+	sizes, err := client.catalog.ContainerSizes(ctx)
+	if err != nil {
+		return fmt.Errorf("list container sizes: %v", err)
+	}
+
+	sizeNames := make([]string, 0, len(sizes))
+	for _, size := range sizes {
+		sizeNames = append(sizeNames, size.Name)
+	}
+	return validateValue("container size", size, sizeNames)
+}
+
+// validateStack accepts both the ID and the name of a stack.
+func validateStack(ctx context.Context, client *providerMeta, stack string) error {
+	stacks, err := client.catalog.Stacks(ctx)
+	if err != nil {
+		return fmt.Errorf("list stacks: %v", err)
+	}
+
+	stackIDs := make([]string, 0, len(stacks))
+	for _, s := range stacks {
+		if s.Name == stack {
+			return nil
+		}
+		stackIDs = append(stackIDs, s.ID)
+	}
+	return validateValue("stack", stack, stackIDs)
+}
+
+// deprecatedStackWarning returns a warning if the stack, given by ID or by
+// name, is deprecated or is about to be. Nothing is returned if the stacks
+// can't be listed: the warning is not worth failing the operation.
+func deprecatedStackWarning(ctx context.Context, client *providerMeta, stack string) diag.Diagnostics {
+	stacks, err := client.catalog.Stacks(ctx)
+	if err != nil {
+		return nil
+	}
+	for _, s := range stacks {
+		if s.ID != stack && s.Name != stack || s.DeprecatedAt.IsZero() {
+			continue
+		}
+		when := "has been deprecated since"
+		if !s.IsDeprecated() {
+			when = "will be deprecated on"
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Stack %v %v %v", s.Name, when, s.DeprecatedAt.Format(time.DateOnly)),
+			Detail:   "Migrate the application to a more recent stack by changing its stack_id.",
+		}}
+	}
+	return nil
+}
+
+// closestValues returns up to 3 possible values close enough to the value to
+// be considered a typo, the closest first.
+func closestValues(value string, possibleValues []string) []string {
+	type candidate struct {
+		value    string
+		distance int
+	}
+
+	maxDistance := max(1, len(value)/3)
+	candidates := []candidate{}
+	for _, possibleValue := range possibleValues {
+		distance := levenshtein(strings.ToLower(value), strings.ToLower(possibleValue))
+		// Values starting with the given one are suggested as well, e.g.
+		// "rpm_per_container" for "rpm".
+		if distance <= maxDistance || strings.HasPrefix(strings.ToLower(possibleValue), strings.ToLower(value)) {
+			candidates = append(candidates, candidate{value: possibleValue, distance: distance})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+
+	values := []string{}
+	for i := 0; i < len(candidates) && i < 3; i++ {
+		values = append(values, candidates[i].value)
+	}
+	return values
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// validateContainerTypeName returns an error if the value can't be the name
+// of a container type, e.g. if it is empty.
+func validateContainerTypeName(value interface{}, path cty.Path) diag.Diagnostics {
+	name, _ := value.(string)
+	if name == "" || strings.ContainsFunc(name, unicode.IsSpace) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("invalid container type name %q: it must not be empty nor contain spaces", name),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package scalingo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Scalingo/go-scalingo/v11"
+)
+
+func TestValidateValue(t *testing.T) {
+	possibleValues := []string{"S", "M", "L", "XL", "2XL"}
+
+	if err := validateValue("container size", "XL", possibleValues); err != nil {
+		t.Errorf("expected XL to be valid, got %v", err)
+	}
+
+	err := validateValue("container size", "xl", possibleValues)
+	if err == nil {
+		t.Fatal("expected an error for an unknown value")
+	}
+	expected := `"xl" is not a valid container size, did you mean "XL" or "L" or "2XL"? Possible values are: S, M, L, XL, 2XL`
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+
+	err = validateValue("container size", "gigantic", possibleValues)
+	expected = `"gigantic" is not a valid container size. Possible values are: S, M, L, XL, 2XL`
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+}
+
+func TestClosestValues(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected []string
+	}{
+		"typo":           {value: "memroy", expected: []string{"memory"}},
+		"prefix":         {value: "rpm", expected: []string{"rpm_per_container"}},
+		"case":           {value: "CPU", expected: []string{"cpu"}},
+		"nothing close":  {value: "latency", expected: []string{}},
+		"missing letter": {value: "swp", expected: []string{"swap"}},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			values := closestValues(test.value, metrics)
+			if !slices.Equal(values, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, values)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+		{"scalingo-22", "scalingo-24", 1},
+		{"été", "ete", 2},
+	}
+
+	for _, test := range tests {
+		if distance := levenshtein(test.a, test.b); distance != test.expected {
+			t.Errorf("levenshtein(%q, %q): expected %d, got %d", test.a, test.b, test.expected, distance)
+		}
+	}
+}
+
+func TestValidation_AtPlanTime(t *testing.T) {
+	f, meta := newTestProvider(t)
+	app := f.createApp(scalingo.AppsCreateOpts{Name: "my-app"})
+
+	tests := map[string]struct {
+		resource string
+		raw      map[string]any
+		expected string
+	}{
+		"addon provider": {
+			resource: "scalingo_addon",
+			raw:      map[string]any{"app": app.ID, "provider_id": "mailjt", "plan": "free"},
+			expected: `"mailjt" is not a valid addon provider, did you mean "mailjet"?`,
+		},
+		"container size": {
+			resource: "scalingo_container_type",
+			raw:      map[string]any{"app": app.ID, "name": "web", "amount": 1, "size": "XXL"},
+			expected: `"XXL" is not a valid container size, did you mean "XL"?`,
+		},
+		"stack": {
+			resource: "scalingo_app",
+			raw:      map[string]any{"name": "other-app", "stack_id": "st-scalingo-23"},
+			expected: `"st-scalingo-23" is not a valid stack, did you mean "st-scalingo-20" or "st-scalingo-22" or "st-scalingo-24"?`,
+		},
+		"alert metric": {
+			resource: "scalingo_alert",
+			raw:      map[string]any{"app": app.ID, "container_type": "web", "metric": "ram", "limit": 0.8},
+			expected: `"ram" is not a valid metric. Possible values are: cpu, memory, swap`,
+		},
+		"autoscaler metric": {
+			resource: "scalingo_autoscaler",
+			raw: map[string]any{
+				"app": app.ID, "container_type": "web", "min_containers": 2, "max_containers": 5, "metric": "cpuu", "target": 0.75,
+			},
+			expected: `"cpuu" is not a valid metric, did you mean "cpu"?`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, diags := planResource(t, test.resource, meta, nil, test.raw)
+			assertDiagContains(t, diags, test.expected)
+		})
+	}
+
+	// Nothing has been created by the failed plans.
+	if len(f.apps) != 1 || len(app.addons) != 0 || len(app.alerts) != 0 || len(app.autoscalers) != 0 {
+		t.Error("no resource should have been created")
+	}
+}
+
+func TestValidation_StackName(t *testing.T) {
+	_, meta := newTestProvider(t)
+
+	_, diags := planResource(t, "scalingo_app", meta, nil, map[string]any{"name": "my-app", "stack_id": "scalingo-24"})
+	if diags.HasError() {
+		t.Errorf("expected a stack name to be accepted, got %v", diags)
+	}
+}
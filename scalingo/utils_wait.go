@@ -3,15 +3,22 @@ package scalingo
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 )
 
-const defaultWaitInterval = 5 * time.Second
+// defaultWaitInterval is a variable so that tests can poll faster.
+var defaultWaitInterval = 5 * time.Second
 
 type waitOptions struct {
-	timeout    time.Duration
-	interval   time.Duration
-	timeoutErr error
+	timeout  time.Duration
+	interval time.Duration
+	// operation describes what is waited for, e.g. "addon provisioning". It is
+	// used in the timeout error.
+	operation string
+	// status returns the last observed status of the object being waited for.
+	// It is used in the timeout error.
+	status func() string
 }
 
 func waitUntil(ctx context.Context, opts waitOptions, check func() (bool, error)) error {
@@ -20,6 +27,7 @@ func waitUntil(ctx context.Context, opts waitOptions, check func() (bool, error)
 	} else if opts.interval < 0 {
 		return errors.New("wait interval must be positive")
 	}
+	start := time.Now()
 
 	done, err := check()
 	if err != nil {
@@ -43,12 +51,14 @@ func waitUntil(ctx context.Context, opts waitOptions, check func() (bool, error)
 	for {
 		select {
 		case <-ctx.Done():
+			// The context of the Terraform operation expires with the timeout
+			// configured in the timeouts block of the resource.
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return opts.timeoutError(time.Since(start))
+			}
 			return ctx.Err()
 		case <-timeout:
-			if opts.timeoutErr != nil {
-				return opts.timeoutErr
-			}
-			return errors.New("timed out waiting for condition")
+			return opts.timeoutError(time.Since(start))
 		case <-ticker.C:
 			done, err := check()
 			if err != nil {
@@ -60,3 +70,18 @@ func waitUntil(ctx context.Context, opts waitOptions, check func() (bool, error)
 		}
 	}
 }
+
+func (opts waitOptions) timeoutError(elapsed time.Duration) error {
+	operation := opts.operation
+	if operation == "" {
+		operation = "condition"
+	}
+	msg := fmt.Sprintf("timed out after %v waiting for %s", elapsed.Round(time.Second), operation)
+
+	if opts.status != nil {
+		if status := opts.status(); status != "" {
+			msg += fmt.Sprintf(" (last status: %s)", status)
+		}
+	}
+	return errors.New(msg)
+}
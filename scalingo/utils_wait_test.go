@@ -0,0 +1,67 @@
+package scalingo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitUntil(t *testing.T) {
+	notDone := func() (bool, error) { return false, nil }
+	opts := waitOptions{
+		interval:  time.Millisecond,
+		operation: "addon ad-1 to be provisioned",
+		status:    func() string { return "provisioning" },
+	}
+
+	t.Run("done", func(t *testing.T) {
+		calls := 0
+		err := waitUntil(context.Background(), opts, func() (bool, error) {
+			calls++
+			return calls == 3, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("expected 3 checks, got %d", calls)
+		}
+	})
+
+	t.Run("check error", func(t *testing.T) {
+		expected := errors.New("boom")
+		err := waitUntil(context.Background(), opts, func() (bool, error) { return false, expected })
+		if !errors.Is(err, expected) {
+			t.Errorf("expected %v, got %v", expected, err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		opts := opts
+		opts.timeout = 20 * time.Millisecond
+		err := waitUntil(context.Background(), opts, notDone)
+		if err == nil || !strings.Contains(err.Error(), "waiting for addon ad-1 to be provisioned (last status: provisioning)") {
+			t.Errorf("expected a timeout error naming the operation and its status, got %v", err)
+		}
+	})
+
+	t.Run("context deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := waitUntil(ctx, opts, notDone)
+		if err == nil || !strings.Contains(err.Error(), "timed out after") || !strings.Contains(err.Error(), "last status: provisioning") {
+			t.Errorf("expected a timeout error, got %v", err)
+		}
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := waitUntil(ctx, opts, notDone)
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}